@@ -0,0 +1,111 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jusgaga/wordmon-go/db/migrations"
+	"github.com/jusgaga/wordmon-go/internal/config"
+	_ "github.com/lib/pq"
+)
+
+const usage = `usage: migrate [-config FICHIER] [-dsn URL] <commande>
+
+commandes:
+  up          applique les migrations en attente
+  down [N]    annule les N dernières migrations appliquées (1 par défaut)
+  status      affiche l'état de chaque migration
+  baseline N  marque les migrations jusqu'à N comme appliquées, sans les exécuter
+              (adoption d'une base créée avant le suivi des migrations)
+`
+
+func main() {
+	var dsn, configPath string
+	flag.StringVar(&configPath, "config", "configs/database.yaml", "configuration de la base (surchargée par WORDMON_DB_*)")
+	flag.StringVar(&dsn, "dsn", "", "URL de connexion PostgreSQL (prioritaire sur -config)")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage); flag.PrintDefaults() }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if dsn == "" {
+		dbConfig, err := config.LoadDatabaseConfig(configPath)
+		if err != nil {
+			log.Fatal("[migrate] Configuration de la base invalide: ", err)
+		}
+		dsn = dbConfig.DSN()
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatal("[migrate] Connexion impossible:", err)
+	}
+	defer db.Close()
+
+	switch cmd := flag.Arg(0); cmd {
+	case "up":
+		done, err := migrations.Up(db)
+		for _, m := range done {
+			log.Printf("[migrate] appliquée: %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal("[migrate] Échec: ", err)
+		}
+		if len(done) == 0 {
+			log.Printf("[migrate] Schéma à jour")
+		}
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			steps, err = strconv.Atoi(flag.Arg(1))
+			if err != nil || steps <= 0 {
+				log.Fatalf("[migrate] Nombre d'étapes invalide: %q", flag.Arg(1))
+			}
+		}
+		done, err := migrations.Down(db, steps)
+		for _, m := range done {
+			log.Printf("[migrate] annulée: %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal("[migrate] Échec: ", err)
+		}
+	case "baseline":
+		if flag.NArg() < 2 {
+			log.Fatal("[migrate] Version requise: baseline N")
+		}
+		version, err := strconv.Atoi(flag.Arg(1))
+		if err != nil || version <= 0 {
+			log.Fatalf("[migrate] Version invalide: %q", flag.Arg(1))
+		}
+		done, err := migrations.Baseline(db, version)
+		if err != nil {
+			log.Fatal("[migrate] Échec: ", err)
+		}
+		for _, m := range done {
+			log.Printf("[migrate] marquée appliquée: %04d_%s", m.Version, m.Name)
+		}
+	case "status":
+		statuses, err := migrations.StatusOf(db)
+		if err != nil {
+			log.Fatal("[migrate] Échec: ", err)
+		}
+		for _, s := range statuses {
+			state := "en attente"
+			if s.Applied {
+				state = "appliquée le " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-20s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "commande inconnue: %s\n\n", cmd)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
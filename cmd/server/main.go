@@ -3,13 +3,14 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/jusgaga/wordmon-go/db/migrations"
 	"github.com/jusgaga/wordmon-go/internal/api"
 	"github.com/jusgaga/wordmon-go/internal/config"
 	"github.com/jusgaga/wordmon-go/internal/core"
@@ -33,10 +34,16 @@ func main() {
 	var (
 		showVersion bool
 		port        string
+		seed        int64
+		eventsPath  string
+		autoMigrate bool
 	)
 	flag.BoolVar(&showVersion, "version", false, "affiche la version")
 	flag.BoolVar(&showVersion, "v", false, "affiche la version (abrégé)")
-	flag.StringVar(&port, "port", "8080", "port du serveur HTTP")
+	flag.StringVar(&port, "port", "", "port du serveur HTTP (prioritaire sur configs/api.yaml)")
+	flag.Int64Var(&seed, "seed", 0, "graine du hasard pour rejouer une partie (0 = aléatoire)")
+	flag.StringVar(&eventsPath, "events", "", "journal d'événements JSONL (vide = table events de la base)")
+	flag.BoolVar(&autoMigrate, "auto-migrate", false, "applique les migrations en attente au démarrage")
 
 	flag.Parse()
 
@@ -45,20 +52,24 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Créer le store SQL avec retry
-	databaseURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		"postgres", "postgres", "localhost", "5432", "wordmon", "disable")
+	// Configuration de la base (configs/database.yaml, surchargée par WORDMON_DB_*)
+	dbConfig, err := config.LoadDatabaseConfig("configs/database.yaml")
+	if err != nil {
+		log.Fatal("[main] Configuration de la base invalide:", err)
+	}
 
+	// Créer le store SQL avec retry
 	var sqlStore *api.SQLStore
-	maxRetries := 30
-	retryDelay := 2 * time.Second
+	maxRetries := dbConfig.Database.Retry.Count
+	retryDelay := dbConfig.RetryDelay()
 
-	log.Printf("[main] Tentative de connexion à la base de données...")
+	log.Printf("[main] Tentative de connexion à la base de données %s...", dbConfig.Redacted())
 
 	for i := 0; i < maxRetries; i++ {
 		var err error
-		sqlStore, err = api.NewSQLStore(databaseURL)
+		sqlStore, err = api.NewSQLStore(dbConfig.DSN())
 		if err == nil {
+			dbConfig.ConfigurePool(sqlStore.DB())
 			log.Printf("[main] Connexion à la base de données réussie!")
 			break
 		}
@@ -75,29 +86,75 @@ func main() {
 
 	defer sqlStore.Close()
 
-	// Charger les mots dans la base de données
-	words := gameData.Words
-	coreWords := make([]core.Word, len(words))
-	for i, word := range words {
-		coreWords[i] = core.Word{
-			ID:     word.ID,
-			Text:   word.Text,
-			Rarity: core.Rarity(word.Rarity),
-			Points: 10, // Points par défaut
+	// Vérifier le schéma: migrations en attente appliquées (-auto-migrate) ou refus de démarrer
+	pending, err := migrations.Pending(sqlStore.DB())
+	if err != nil {
+		log.Fatal("[main] Vérification des migrations impossible:", err)
+	}
+	if len(pending) > 0 {
+		if !autoMigrate {
+			log.Fatalf("[main] %d migration(s) en attente (dont %04d_%s): lancez `go run ./cmd/migrate up` ou démarrez avec -auto-migrate",
+				len(pending), pending[0].Version, pending[0].Name)
+		}
+		done, err := migrations.Up(sqlStore.DB())
+		for _, m := range done {
+			log.Printf("[main] Migration appliquée: %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal("[main] Échec des migrations:", err)
 		}
 	}
 
-	if err := sqlStore.Seed(coreWords); err != nil {
+	// Charger les mots dans la base de données (points issus de xpRewards)
+	if err := sqlStore.Seed(gameData.CoreWords()); err != nil {
 		log.Fatal("[main] Échec du seeding de la base de données:", err)
 	}
 
+	// Règles de jeu et spawner construits depuis la configuration
+	var rng core.Rand
+	if seed != 0 {
+		rng = core.NewRand(seed)
+		log.Printf("[main] Hasard initialisé avec la graine %d", seed)
+	}
+	rules, err := gameData.Rules(rng)
+	if err != nil {
+		log.Fatal("[main] Configuration du spawner invalide:", err)
+	}
+
+	// Journal d'événements: fichier JSONL si demandé, sinon table events
+	if eventsPath != "" {
+		fileLog, err := core.NewFileEventLog(eventsPath)
+		if err != nil {
+			log.Fatal("[main] Journal d'événements indisponible:", err)
+		}
+		defer fileLog.Close()
+		rules.Events = fileLog
+		log.Printf("[main] Journal d'événements: %s", eventsPath)
+	} else {
+		rules.Events = sqlStore.EventLog()
+	}
+
+	// Configuration de l'API (configs/api.yaml)
+	apiConfig, err := config.LoadAPIConfig("configs/api.yaml")
+	if err != nil {
+		log.Fatal("[main] Configuration de l'API invalide:", err)
+	}
+	addr := apiConfig.Addr()
+	if port != "" {
+		addr = net.JoinHostPort(apiConfig.API.Server.Host, port)
+	}
+
 	// Créer le serveur API avec le store SQL
-	server := api.NewServer(sqlStore, sqlStore)
+	server := api.NewServer(sqlStore, sqlStore, apiConfig)
+	server.SetRules(rules)
 
 	// Gestion de l'arrêt propre
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Nettoyage des rencontres dont le WordMon s'est enfui
+	go server.GetHandlers().Encounters().StartReaper(ctx, time.Second)
+
 	// Configurer le spawner
 	spawnInterval := gameData.Game.SpawnInterval()
 	if spawnInterval == 0 {
@@ -112,17 +169,11 @@ func main() {
 		ticker := time.NewTicker(spawnInterval)
 		defer ticker.Stop()
 
-		round := 0
 		for {
 			select {
-			case <-ticker.C:
-				round++
-				word := core.SpawnWord()
-
-				spawnEvent := core.SpawnEvent{
-					Round: round,
-					Word:  word,
-				}
+			case now := <-ticker.C:
+				spawnEvent := rules.Spawner.Next(now)
+				word := spawnEvent.Word
 
 				log.Printf("[spawn] Nouveau WordMon: %q (%s)", word.Text, word.Rarity)
 
@@ -145,7 +196,20 @@ func main() {
 		for {
 			select {
 			case spawnEvent := <-spawnCh:
-				sqlStore.AddSpawn(spawnEvent)
+				if err := sqlStore.AddSpawn(spawnEvent); err != nil {
+					// Spawn non enregistré: ni annoncé ni journalisé, personne ne pourrait le combattre
+					log.Printf("[spawn] échec d'enregistrement du spawn: %v", err)
+					continue
+				}
+				server.PublishSpawn(spawnEvent)
+				if err := rules.Events.Append(core.Event{
+					Version: core.EventVersion,
+					Type:    core.EventSpawn,
+					At:      time.Now(),
+					Spawn:   core.NewSpawnRecord(spawnEvent),
+				}); err != nil {
+					log.Printf("[events] échec d'écriture du spawn: %v", err)
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -169,7 +233,6 @@ func main() {
 	}()
 
 	// Démarrer le serveur HTTP
-	addr := ":" + port
 	log.Printf("[main] Démarrage du serveur API sur %s", addr)
 	log.Printf("[main] Spawner démarré avec intervalle: %v", spawnInterval)
 
@@ -0,0 +1,2 @@
+ALTER TABLE players
+ ADD COLUMN rewards JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,10 @@
+CREATE TABLE events (
+ id BIGSERIAL PRIMARY KEY,
+ version INT NOT NULL,
+ type TEXT NOT NULL,
+ at TIMESTAMPTZ NOT NULL,
+ player_id TEXT,
+ payload JSONB NOT NULL
+);
+
+CREATE INDEX events_player_id_idx ON events (player_id, id);
@@ -0,0 +1,13 @@
+CREATE TABLE spawns (
+ id TEXT PRIMARY KEY,
+ round INT NOT NULL,
+ word_id TEXT NOT NULL,
+ word TEXT NOT NULL,
+ rarity TEXT NOT NULL,
+ points INT NOT NULL,
+ spawned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+ expires_at TIMESTAMPTZ,
+ claimed_by UUID REFERENCES players(id) ON DELETE SET NULL
+);
+
+CREATE INDEX spawns_spawned_at_idx ON spawns (spawned_at DESC);
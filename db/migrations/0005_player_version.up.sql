@@ -0,0 +1,2 @@
+ALTER TABLE players
+ ADD COLUMN version INT NOT NULL DEFAULT 0;
@@ -0,0 +1,255 @@
+// Package migrations embarque les migrations SQL du schéma WordMon et les applique.
+// Chaque migration NNNN_nom est composée d'un fichier .up.sql et d'un fichier
+// .down.sql; les versions appliquées sont enregistrées dans schema_migrations.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Migration est une étape du schéma, identifiée par sa version.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status indique si une migration est appliquée, et depuis quand.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// MigrationError signale un fichier de migration mal formé ou une migration en échec.
+type MigrationError struct {
+	File   string
+	Reason string
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("migration %s: %s", e.File, e.Reason)
+}
+
+// fileName reconnaît les fichiers NNNN_nom.up.sql et NNNN_nom.down.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+ version INT PRIMARY KEY,
+ name TEXT NOT NULL,
+ applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// All retourne les migrations embarquées, triées par version croissante.
+func All() ([]Migration, error) {
+	return parse(files)
+}
+
+// parse lit les migrations d'un système de fichiers: chaque version doit avoir
+// un fichier up et un fichier down portant le même nom.
+func parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("lecture des migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		m := fileName.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("lecture de la migration %s: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, &MigrationError{File: entry.Name(), Reason: fmt.Sprintf("version %d déjà utilisée par %s", version, mig.Name)}
+		}
+		if m[3] == "up" {
+			mig.Up = string(content)
+		} else {
+			mig.Down = string(content)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, &MigrationError{File: fmt.Sprintf("%04d_%s", mig.Version, mig.Name), Reason: "fichier up ou down manquant"}
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// StatusOf retourne l'état de chaque migration embarquée dans la base.
+func StatusOf(db *sql.DB) ([]Status, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Status, 0, len(all))
+	for _, mig := range all {
+		at, ok := applied[mig.Version]
+		out = append(out, Status{Migration: mig, Applied: ok, AppliedAt: at})
+	}
+	return out, nil
+}
+
+// Pending retourne les migrations embarquées qui ne sont pas encore appliquées.
+func Pending(db *sql.DB) ([]Migration, error) {
+	statuses, err := StatusOf(db)
+	if err != nil {
+		return nil, err
+	}
+	var out []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			out = append(out, s.Migration)
+		}
+	}
+	return out, nil
+}
+
+// Up applique les migrations en attente dans l'ordre, chacune dans sa transaction.
+// Retourne les migrations appliquées; la première en échec interrompt la série.
+func Up(db *sql.DB) ([]Migration, error) {
+	pending, err := Pending(db)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, mig := range pending {
+		err := inTx(db, mig.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+		if err != nil {
+			return done, &MigrationError{File: fmt.Sprintf("%04d_%s.up.sql", mig.Version, mig.Name), Reason: err.Error()}
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down annule les steps dernières migrations appliquées, de la plus récente à la plus ancienne.
+func Down(db *sql.DB, steps int) ([]Migration, error) {
+	statuses, err := StatusOf(db)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := statuses[i]
+		if !mig.Applied {
+			continue
+		}
+		err := inTx(db, mig.Down, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		if err != nil {
+			return done, &MigrationError{File: fmt.Sprintf("%04d_%s.down.sql", mig.Version, mig.Name), Reason: err.Error()}
+		}
+		done = append(done, mig.Migration)
+	}
+	return done, nil
+}
+
+// Baseline marque comme appliquées, sans exécuter leur script, les migrations en attente
+// de version inférieure ou égale à version. Elle sert à adopter une base dont le schéma
+// a été créé avant le suivi des migrations; version doit être une migration embarquée.
+func Baseline(db *sql.DB, version int) ([]Migration, error) {
+	statuses, err := StatusOf(db)
+	if err != nil {
+		return nil, err
+	}
+	known := false
+	var marked []Migration
+	for _, s := range statuses {
+		if s.Version == version {
+			known = true
+		}
+		if s.Version <= version && !s.Applied {
+			marked = append(marked, s.Migration)
+		}
+	}
+	if !known {
+		return nil, &MigrationError{File: fmt.Sprintf("%04d", version), Reason: "version inconnue"}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	for _, mig := range marked {
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return marked, nil
+}
+
+// appliedVersions crée schema_migrations au besoin et retourne les versions appliquées.
+func appliedVersions(db *sql.DB) (map[int]time.Time, error) {
+	if _, err := db.Exec(createTable); err != nil {
+		return nil, fmt.Errorf("création de schema_migrations: %w", err)
+	}
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("lecture de schema_migrations: %w", err)
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// inTx exécute le script de migration puis la mise à jour de schema_migrations
+// dans une même transaction.
+func inTx(db *sql.DB, script, record string, args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(script); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(record, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAll(t *testing.T) {
+	all, err := All()
+	if err != nil {
+		t.Fatalf("All ne devrait pas échouer: %v", err)
+	}
+	if len(all) == 0 || all[0].Version != 1 || all[0].Name != "init" {
+		t.Fatalf("All() = %+v, attendu 0001_init en premier", all)
+	}
+	for i, mig := range all {
+		if mig.Up == "" || mig.Down == "" {
+			t.Errorf("migration %d sans script up ou down", mig.Version)
+		}
+		if i > 0 && mig.Version <= all[i-1].Version {
+			t.Errorf("migrations non triées: %d après %d", mig.Version, all[i-1].Version)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"Down manquant", fstest.MapFS{
+			"0001_init.up.sql": {Data: []byte("CREATE TABLE a ();")},
+		}},
+		{"Version en double", fstest.MapFS{
+			"0001_init.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+			"0001_init.down.sql":  {Data: []byte("DROP TABLE a;")},
+			"0001_other.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+			"0001_other.down.sql": {Data: []byte("DROP TABLE b;")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.fsys)
+			var migErr *MigrationError
+			if !errors.As(err, &migErr) {
+				t.Errorf("parse devrait retourner une MigrationError, got %v", err)
+			}
+		})
+	}
+
+	all, err := parse(fstest.MapFS{
+		"0002_b.up.sql":   {Data: []byte("b")},
+		"0002_b.down.sql": {Data: []byte("-b")},
+		"0001_a.up.sql":   {Data: []byte("a")},
+		"0001_a.down.sql": {Data: []byte("-a")},
+		"README.md":       {Data: []byte("ignoré")},
+	})
+	if err != nil || len(all) != 2 || all[0].Name != "a" || all[1].Down != "-b" {
+		t.Errorf("parse = %+v, %v", all, err)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	all, err := All()
+	if err != nil || len(all) < 3 {
+		t.Fatalf("All() = %+v, %v", all, err)
+	}
+
+	// 0001 déjà enregistrée: seules 0002 et 0003 sont marquées, sans exécuter leur script
+	expectApplied := func(versions ...int) {
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"version", "applied_at"})
+		for _, v := range versions {
+			rows.AddRow(v, time.Now())
+		}
+		mock.ExpectQuery(`SELECT version, applied_at FROM schema_migrations`).WillReturnRows(rows)
+	}
+	expectApplied(1)
+	mock.ExpectBegin()
+	for _, mig := range all[1:3] {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`)).
+			WithArgs(mig.Version, mig.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	marked, err := Baseline(db, 3)
+	if err != nil || len(marked) != 2 || marked[0].Version != 2 || marked[1].Version != 3 {
+		t.Errorf("Baseline(3) = %+v, %v ; attendu 0002 et 0003", marked, err)
+	}
+
+	// Version absente des migrations embarquées: rien n'est enregistré
+	expectApplied(1)
+	var migErr *MigrationError
+	if _, err := Baseline(db, 999); !errors.As(err, &migErr) {
+		t.Errorf("Baseline(999): MigrationError attendue, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
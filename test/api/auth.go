@@ -0,0 +1,129 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authPlayerKey est la clé du contexte gin portant le joueur authentifié
+const authPlayerKey = "authPlayerID"
+
+// TokenSigner émet et vérifie des jetons de session signés (JWT HS256)
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// tokenHeader est l'en-tête fixe des jetons émis
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// tokenClaims représente le contenu d'un jeton: joueur, émission et expiration (secondes Unix)
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// NewTokenSigner crée un signataire de jetons valables ttl
+func NewTokenSigner(secret string, ttl time.Duration) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue émet un jeton pour le joueur et retourne son expiration
+func (s *TokenSigner) Issue(playerID string) (string, time.Time) {
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims, _ := json.Marshal(tokenClaims{Subject: playerID, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix()})
+	payload := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return payload + "." + s.sign(payload), expiresAt
+}
+
+// Verify vérifie la signature et l'expiration du jeton et retourne son joueur
+func (s *TokenSigner) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return "", &InvalidTokenError{Reason: "format invalide"}
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return "", &InvalidTokenError{Reason: "signature invalide"}
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", &InvalidTokenError{Reason: "contenu illisible"}
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(raw, &claims); err != nil || claims.Subject == "" {
+		return "", &InvalidTokenError{Reason: "contenu illisible"}
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return "", &InvalidTokenError{Reason: "jeton expiré"}
+	}
+	return claims.Subject, nil
+}
+
+// sign retourne la signature HMAC-SHA256 encodée en base64url
+func (s *TokenSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// RequireAuth associe la requête au joueur du jeton Bearer; sans authentification configurée, laisse passer
+func (h *Handlers) RequireAuth(c *gin.Context) {
+	if h.tokens == nil {
+		c.Next()
+		return
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Jeton d'authentification requis (Authorization: Bearer ...)",
+		})
+		return
+	}
+	playerID, err := h.tokens.Verify(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Set(authPlayerKey, playerID)
+	c.Next()
+}
+
+// authorize vérifie que la requête porte sur le joueur authentifié et répond 403 sinon;
+// sans authentification configurée, toute requête est autorisée
+func (h *Handlers) authorize(c *gin.Context, playerID string) bool {
+	authID, ok := c.Get(authPlayerKey)
+	if !ok || authID == playerID {
+		return true
+	}
+	c.JSON(http.StatusForbidden, ErrorResponse{
+		Error:   "forbidden",
+		Message: "Ce jeton ne permet pas d'agir pour ce joueur",
+	})
+	return false
+}
+
+// InvalidTokenError erreur quand le jeton est invalide ou expiré
+type InvalidTokenError struct {
+	Reason string
+}
+
+func (e *InvalidTokenError) Error() string {
+	return "jeton invalide: " + e.Reason
+}
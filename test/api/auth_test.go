@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jusgaga/wordmon-go/internal/config"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func TestTokenSigner(t *testing.T) {
+	signer := NewTokenSigner(testJWTSecret, time.Hour)
+	token, expiresAt := signer.Issue("p1")
+	if d := time.Until(expiresAt); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expiration dans %v, attendu ~1h", d)
+	}
+	if id, err := signer.Verify(token); err != nil || id != "p1" {
+		t.Fatalf("Verify = %q, %v ; attendu p1", id, err)
+	}
+
+	parts := strings.Split(token, ".")
+	forged, _ := NewTokenSigner("un autre secret de trente-deux octets", time.Hour).Issue("p1")
+	expired, _ := NewTokenSigner(testJWTSecret, -time.Second).Issue("p1")
+	other, _ := signer.Issue("p2")
+	tests := []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{"format", "pas-un-jeton", "format invalide"},
+		{"autre secret", forged, "signature invalide"},
+		{"contenu modifié", parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2], "signature invalide"},
+		{"expiré", expired, "jeton expiré"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verify(tt.token)
+			var invalid *InvalidTokenError
+			if !errors.As(err, &invalid) || invalid.Reason != tt.reason {
+				t.Errorf("Verify = %v, attendu InvalidTokenError %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	cfg := config.DefaultAPIConfig()
+	cfg.API.Security.EnableAuth = true
+	cfg.API.Security.JWTSecret = testJWTSecret
+	s := newTestServer(cfg)
+
+	create := func(name string) CreatePlayerResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/players", strings.NewReader(`{"name":"`+name+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		s.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("création de %s: status %d (%s)", name, w.Code, w.Body)
+		}
+		var resp CreatePlayerResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Token == "" {
+			t.Fatalf("création de %s: jeton absent (%s)", name, w.Body)
+		}
+		return resp
+	}
+	sacha, ondine := create("sacha"), create("ondine")
+
+	tests := []struct {
+		name       string
+		auth       string
+		wantStatus int
+		wantError  string
+	}{
+		{"sans jeton", "", http.StatusUnauthorized, "unauthorized"},
+		{"schéma inconnu", "Basic " + sacha.Token, http.StatusUnauthorized, "unauthorized"},
+		{"jeton invalide", "Bearer " + sacha.Token + "x", http.StatusUnauthorized, "unauthorized"},
+		{"jeton d'un autre joueur", "Bearer " + ondine.Token, http.StatusForbidden, "forbidden"},
+		{"jeton du joueur", "Bearer " + sacha.Token, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/players/"+sacha.ID, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			s.router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, attendu %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantError != "" {
+				var resp ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Error != tt.wantError {
+					t.Errorf("erreur = %q, attendu %q", resp.Error, tt.wantError)
+				}
+			}
+		})
+	}
+}
+
+func TestRequireAuth_Disabled(t *testing.T) {
+	s := newTestServer(nil)
+	player, err := s.handlers.playerStore.CreatePlayer("sacha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/players/"+player.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("sans authentification configurée: status %d, attendu 200", w.Code)
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// CaptureResult représente le joueur après une capture et les niveaux franchis
+type CaptureResult struct {
+	Player   *PlayerResponse
+	LevelUps []core.LevelUp
+}
+
+// Capture enregistre la capture en mémoire: inventaire, XP, niveau et récompenses
+// sont calculés sur une copie puis appliqués en une fois, ou pas du tout en cas d'erreur
+func (s *SimpleStore) Capture(playerID string, word core.Word, rules *core.Rules) (*CaptureResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player, exists := s.players[playerID]
+	if !exists {
+		return nil, &PlayerNotFoundError{ID: playerID}
+	}
+
+	p := toCorePlayer(player)
+	ups, err := awardCapture(&p, word, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *player
+	applyCorePlayer(&updated, p)
+	updated.Version++
+	s.players[playerID] = &updated
+	return &CaptureResult{Player: clonePlayer(&updated), LevelUps: ups}, nil
+}
+
+// Capture enregistre la capture dans une transaction: insertion dans captures,
+// mise à jour de l'XP, du niveau et des récompenses du joueur; annulée en cas d'erreur
+func (s *SQLStore) Capture(playerID string, word core.Word, rules *core.Rules) (result *CaptureResult, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("erreur début transaction capture: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("erreur annulation capture: %v", rbErr)
+			}
+		}
+	}()
+
+	// Verrouiller la ligne du joueur jusqu'à la fin de la transaction
+	query := `SELECT id, name, xp, level, version, rewards FROM players WHERE id = $1 FOR UPDATE`
+
+	var player PlayerResponse
+	var rewards []byte
+	err = tx.QueryRow(query, playerID).Scan(&player.ID, &player.Name, &player.XP, &player.Level, &player.Version, &rewards)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &PlayerNotFoundError{ID: playerID}
+		}
+		return nil, fmt.Errorf("erreur récupération joueur: %w", err)
+	}
+	if err = json.Unmarshal(rewards, &player.PlayerRewards); err != nil {
+		return nil, fmt.Errorf("erreur lecture récompenses: %w", err)
+	}
+
+	query = `INSERT INTO captures (id, player_id, word_id) VALUES ($1, $2, $3)`
+	if _, err = tx.Exec(query, uuid.New().String(), playerID, word.ID); err != nil {
+		return nil, fmt.Errorf("erreur ajout capture: %w", err)
+	}
+
+	inventory, err := listCaptures(tx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur récupération inventaire: %w", err)
+	}
+	player.Inventory = make(map[string]int)
+	for _, w := range inventory {
+		player.Inventory[w.Text]++
+	}
+
+	// L'inventaire relu contient déjà la capture: seuls XP, niveau et récompenses restent à calculer
+	p := toCorePlayer(&player)
+	ups, err := core.AwardXPWith(&p, word.Points, rules.LevelCurve(), rules.Rewards)
+	if err != nil {
+		return nil, fmt.Errorf("erreur attribution XP: %w", err)
+	}
+	applyCorePlayer(&player, p)
+
+	if rewards, err = json.Marshal(player.PlayerRewards); err != nil {
+		return nil, fmt.Errorf("erreur encodage récompenses: %w", err)
+	}
+	query = `UPDATE players SET xp = $1, level = $2, rewards = $3, version = version + 1 WHERE id = $4`
+	if _, err = tx.Exec(query, player.XP, player.Level, string(rewards), playerID); err != nil {
+		return nil, fmt.Errorf("erreur mise à jour joueur: %w", err)
+	}
+	player.Version++
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erreur validation capture: %w", err)
+	}
+
+	log.Printf("[api] Capture: %s -> %s (+%d XP)", player.Name, word.Text, word.Points)
+	return &CaptureResult{Player: &player, LevelUps: ups}, nil
+}
+
+// awardCapture ajoute le mot à l'inventaire puis attribue l'XP et les récompenses des règles
+func awardCapture(p *core.Player, word core.Word, rules *core.Rules) ([]core.LevelUp, error) {
+	points, err := core.Capture(p, word)
+	if err != nil {
+		return nil, err
+	}
+	return core.AwardXPWith(p, points, rules.LevelCurve(), rules.Rewards)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// captureRules attribue un titre au niveau 2, atteint dès 100 XP
+func captureRules() *core.Rules {
+	rules := core.DefaultRules()
+	rules.Rewards = core.RewardTable{2: {Title: "Dresseur"}}
+	return rules
+}
+
+var captureWord = core.Word{ID: "w1", Text: "chien", Rarity: core.Common, Points: 120}
+
+func TestSimpleStore_Capture(t *testing.T) {
+	store := NewSimpleStore()
+	player, _ := store.CreatePlayer("sacha")
+
+	result, err := store.Capture(player.ID, captureWord, captureRules())
+	if err != nil {
+		t.Fatalf("Capture: %v", err)
+	}
+	got, _ := store.GetPlayer(player.ID)
+	if got.XP != 120 || got.Level != 2 || got.Inventory["chien"] != 1 || got.Version != player.Version+1 {
+		t.Errorf("joueur = %+v, attendu 120 XP, niveau 2, chien capturé, version +1", got)
+	}
+	if !reflect.DeepEqual(got.Titles, []string{"Dresseur"}) {
+		t.Errorf("titres = %v, attendu [Dresseur]", got.Titles)
+	}
+	if len(result.LevelUps) != 1 || result.LevelUps[0].Level != 2 || !reflect.DeepEqual(result.Player, got) {
+		t.Errorf("résultat = %+v, %+v", result.Player, result.LevelUps)
+	}
+
+	// Le résultat est une copie: le modifier ne touche pas au store
+	result.Player.Inventory["chien"] = 99
+	if again, _ := store.GetPlayer(player.ID); again.Inventory["chien"] != 1 {
+		t.Error("le résultat partage l'inventaire du store")
+	}
+}
+
+func TestSimpleStore_Capture_FailureLeavesPlayerUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		word core.Word
+	}{
+		{"mot vide", core.Word{ID: "w0", Points: 10}},
+		// la capture passe, l'XP échoue: rien ne doit rester de la capture
+		{"points négatifs", core.Word{ID: "w1", Text: "chien", Points: -10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSimpleStore()
+			player, _ := store.CreatePlayer("sacha")
+
+			if _, err := store.Capture(player.ID, tt.word, captureRules()); err == nil {
+				t.Fatal("Capture devrait échouer")
+			}
+			if got, _ := store.GetPlayer(player.ID); !reflect.DeepEqual(got, player) {
+				t.Errorf("joueur modifié malgré l'échec: %+v, attendu %+v", got, player)
+			}
+		})
+	}
+
+	var notFound *PlayerNotFoundError
+	if _, err := NewSimpleStore().Capture("absent", captureWord, captureRules()); !errors.As(err, &notFound) {
+		t.Errorf("joueur absent: PlayerNotFoundError attendue, got %v", err)
+	}
+}
+
+// expectCaptureStart attend le début de la transaction, le verrou du joueur p1 (0 XP) et l'insertion de la capture
+func expectCaptureStart(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, xp, level, version, rewards FROM players WHERE id = $1 FOR UPDATE`)).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "xp", "level", "version", "rewards"}).
+			AddRow("p1", "sacha", 0, 1, 3, []byte(`{}`)))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO captures (id, player_id, word_id) VALUES ($1, $2, $3)`)).
+		WithArgs(sqlmock.AnyArg(), "p1", "w1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM words w\s+JOIN captures c`).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "rarity", "points"}).
+			AddRow("w1", "chien", "Common", 120))
+}
+
+func newMockSQLStore(t *testing.T) (*SQLStore, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &SQLStore{db: db}, mock
+}
+
+func TestSQLStore_Capture_Commit(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+	expectCaptureStart(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE players SET xp = $1, level = $2, rewards = $3, version = version + 1 WHERE id = $4`)).
+		WithArgs(120, 2, sqlmock.AnyArg(), "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := store.Capture("p1", captureWord, captureRules())
+	if err != nil {
+		t.Fatalf("Capture: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	// Même calcul que le store en mémoire (à la version près, propre à chaque store)
+	memory := NewSimpleStore()
+	player, _ := memory.CreatePlayer("sacha")
+	want, err := memory.Capture(player.ID, captureWord, captureRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Player.Version != 4 {
+		t.Errorf("version = %d, attendu 4", result.Player.Version)
+	}
+	got, exp := *result.Player, *want.Player
+	got.ID, got.Version, exp.ID, exp.Version = "", 0, "", 0
+	if !reflect.DeepEqual(got, exp) || !reflect.DeepEqual(result.LevelUps, want.LevelUps) {
+		t.Errorf("SQL: %+v %+v\nmémoire: %+v %+v", got, result.LevelUps, exp, want.LevelUps)
+	}
+}
+
+func TestSQLStore_Capture_Rollback(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+	}{
+		{"insertion refusée", func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectQuery(`FOR UPDATE`).WithArgs("p1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "xp", "level", "version", "rewards"}).
+					AddRow("p1", "sacha", 0, 1, 3, []byte(`{}`)))
+			mock.ExpectExec(`INSERT INTO captures`).WillReturnError(errors.New("violation de clé étrangère"))
+			mock.ExpectRollback()
+		}},
+		{"mise à jour du joueur refusée", func(mock sqlmock.Sqlmock) {
+			expectCaptureStart(mock)
+			mock.ExpectExec(`UPDATE players`).WillReturnError(errors.New("connexion perdue"))
+			mock.ExpectRollback()
+		}},
+		{"validation refusée", func(mock sqlmock.Sqlmock) {
+			expectCaptureStart(mock)
+			mock.ExpectExec(`UPDATE players`).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit().WillReturnError(errors.New("sérialisation impossible"))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockSQLStore(t)
+			tt.expect(mock)
+
+			if result, err := store.Capture("p1", captureWord, captureRules()); err == nil {
+				t.Fatalf("Capture devrait échouer, got %+v", result)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	store, mock := newMockSQLStore(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs("absent").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+	var notFound *PlayerNotFoundError
+	if _, err := store.Capture("absent", captureWord, captureRules()); !errors.As(err, &notFound) {
+		t.Errorf("joueur absent: PlayerNotFoundError attendue, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
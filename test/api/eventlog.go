@@ -0,0 +1,75 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// SQLEventLog implémente core.EventLog sur la table events (ajout seul)
+type SQLEventLog struct {
+	db *sql.DB
+}
+
+// NewSQLEventLog crée un journal d'événements sur la base fournie
+func NewSQLEventLog(db *sql.DB) *SQLEventLog {
+	return &SQLEventLog{db: db}
+}
+
+// Append insère l'événement, sérialisé en JSON dans la colonne payload
+func (l *SQLEventLog) Append(ev core.Event) error {
+	if ev.Version == 0 {
+		ev.Version = core.EventVersion
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("erreur encodage événement: %w", err)
+	}
+
+	var playerID sql.NullString
+	if ev.PlayerID != "" {
+		playerID = sql.NullString{String: ev.PlayerID, Valid: true}
+	}
+
+	query := `INSERT INTO events (version, type, at, player_id, payload) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := l.db.Exec(query, ev.Version, string(ev.Type), ev.At, playerID, string(payload)); err != nil {
+		return fmt.Errorf("erreur ajout événement: %w", err)
+	}
+	return nil
+}
+
+// Events récupère les événements d'un joueur (tous si playerID est vide) dans l'ordre d'ajout
+func (l *SQLEventLog) Events(playerID string) ([]core.Event, error) {
+	query := `SELECT id, payload FROM events WHERE $1 = '' OR player_id = $1 ORDER BY id`
+
+	rows, err := l.db.Query(query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur récupération événements: %w", err)
+	}
+	defer rows.Close()
+
+	var events []core.Event
+	for rows.Next() {
+		var id int
+		var payload []byte
+		if err := rows.Scan(&id, &payload); err != nil {
+			return nil, fmt.Errorf("erreur scan événement: %w", err)
+		}
+		var ev core.Event
+		if err := json.Unmarshal(payload, &ev); err != nil {
+			return nil, &core.EventLogError{Line: id, Reason: err.Error()}
+		}
+		events = append(events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erreur lecture événements: %w", err)
+	}
+	return events, nil
+}
+
+// EventLog retourne le journal d'événements partageant la connexion du store
+func (s *SQLStore) EventLog() *SQLEventLog {
+	return NewSQLEventLog(s.db)
+}
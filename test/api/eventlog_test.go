@@ -0,0 +1,150 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+const (
+	insertEventQuery = `INSERT INTO events (version, type, at, player_id, payload) VALUES ($1, $2, $3, $4, $5)`
+	selectEventQuery = `SELECT id, payload FROM events WHERE $1 = '' OR player_id = $1 ORDER BY id`
+)
+
+// eventPayload vérifie que la colonne payload contient l'événement attendu, sérialisé en JSON
+type eventPayload struct{ want core.Event }
+
+func (p eventPayload) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	var got core.Event
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		return false
+	}
+	return got.Version == p.want.Version && got.Type == p.want.Type && got.At.Equal(p.want.At) &&
+		got.PlayerID == p.want.PlayerID && reflect.DeepEqual(got.Capture, p.want.Capture) &&
+		reflect.DeepEqual(got.Spawn, p.want.Spawn)
+}
+
+func newMockEventLog(t *testing.T) (*SQLEventLog, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewSQLEventLog(db), mock
+}
+
+func TestSQLEventLog_Append(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	capture := core.Event{Type: core.EventCapture, At: at, PlayerID: "p1", Capture: &core.CaptureRecord{Word: "chat", Points: 10}}
+	spawn := core.Event{Version: core.EventVersion, Type: core.EventSpawn, At: at, Spawn: &core.SpawnRecord{ID: "s1", Round: 1, Word: "chat", Rarity: core.Common, Points: 10}}
+
+	tests := []struct {
+		name     string
+		ev       core.Event
+		playerID sqlmock.Argument
+	}{
+		// sans version, l'événement est écrit dans la version courante
+		{"capture d'un joueur", capture, sqlmock.AnyArg()},
+		// un événement global n'a pas de joueur: player_id reste NULL
+		{"apparition globale", spawn, nullArg{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, mock := newMockEventLog(t)
+			want := tt.ev
+			want.Version = core.EventVersion
+			mock.ExpectExec(regexp.QuoteMeta(insertEventQuery)).
+				WithArgs(core.EventVersion, string(tt.ev.Type), at, tt.playerID, eventPayload{want}).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			if err := log.Append(tt.ev); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	log, mock := newMockEventLog(t)
+	mock.ExpectExec(regexp.QuoteMeta(insertEventQuery)).WillReturnError(errors.New("connexion perdue"))
+	if err := log.Append(capture); err == nil {
+		t.Error("Append devrait remonter l'erreur d'insertion")
+	}
+}
+
+func TestSQLEventLog_Events(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	spawn := core.Event{Version: 1, Type: core.EventSpawn, At: at, Spawn: &core.SpawnRecord{Round: 1, Word: "chat", Points: 10}}
+	capture := core.Event{Version: 1, Type: core.EventCapture, At: at, PlayerID: "p1", Capture: &core.CaptureRecord{Word: "chat", Points: 10}}
+	row := func(ev core.Event) []byte {
+		data, _ := json.Marshal(ev)
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		playerID string
+		rows     *sqlmock.Rows
+		want     []core.Event
+	}{
+		// la requête filtre par joueur; playerID vide ("$1 = ''") retourne tout le journal
+		{"tous les joueurs", "", sqlmock.NewRows([]string{"id", "payload"}).AddRow(1, row(spawn)).AddRow(2, row(capture)), []core.Event{spawn, capture}},
+		{"un joueur", "p1", sqlmock.NewRows([]string{"id", "payload"}).AddRow(2, row(capture)), []core.Event{capture}},
+		{"journal vide", "p2", sqlmock.NewRows([]string{"id", "payload"}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, mock := newMockEventLog(t)
+			mock.ExpectQuery(regexp.QuoteMeta(selectEventQuery)).WithArgs(tt.playerID).WillReturnRows(tt.rows)
+
+			got, err := log.Events(tt.playerID)
+			if err != nil {
+				t.Fatalf("Events: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Events() = %+v, attendu %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Type != tt.want[i].Type || got[i].PlayerID != tt.want[i].PlayerID || !got[i].At.Equal(tt.want[i].At) {
+					t.Errorf("événement %d = %+v, attendu %+v", i, got[i], tt.want[i])
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSQLEventLog_EventsInvalidPayload(t *testing.T) {
+	log, mock := newMockEventLog(t)
+	mock.ExpectQuery(regexp.QuoteMeta(selectEventQuery)).WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload"}).
+			AddRow(1, []byte(`{"v":1,"type":"spawn"}`)).
+			AddRow(7, []byte(`{"v":1,"type":`)))
+
+	_, err := log.Events("")
+	var logErr *core.EventLogError
+	if !errors.As(err, &logErr) || logErr.Line != 7 {
+		t.Errorf("Events: EventLogError sur l'entrée 7 attendue, got %v", err)
+	}
+
+	log, mock = newMockEventLog(t)
+	mock.ExpectQuery(regexp.QuoteMeta(selectEventQuery)).WillReturnError(errors.New("connexion perdue"))
+	if _, err := log.Events(""); err == nil || errors.As(err, &logErr) {
+		t.Errorf("erreur de requête: erreur simple attendue, got %v", err)
+	}
+}
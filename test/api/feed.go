@@ -0,0 +1,273 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// Types des événements diffusés par le flux
+const (
+	FeedSpawn        = "spawn"
+	FeedSpawnExpired = "spawn_expired"
+	FeedCapture      = "capture"
+	FeedLevelUp      = "level_up"
+)
+
+const (
+	// feedBacklogSize borne les événements conservés pour la reprise (Last-Event-ID)
+	feedBacklogSize = 256
+	// feedClientBuffer borne les événements en attente d'un client; au-delà, il est déconnecté
+	feedClientBuffer = 32
+	// feedHeartbeat est l'intervalle des commentaires envoyés pour garder le flux ouvert
+	feedHeartbeat = 15 * time.Second
+)
+
+// FeedEvent est un événement du flux, numéroté dans l'ordre de publication
+type FeedEvent struct {
+	ID   uint64
+	Type string
+	Data any
+}
+
+// EventHub diffuse les événements du jeu à tous les abonnés.
+// Chaque abonné dispose d'un canal borné: un abonné trop lent est déconnecté
+// plutôt que de bloquer l'émetteur. Les derniers événements sont conservés
+// pour permettre la reprise d'un flux interrompu
+type EventHub struct {
+	mu      sync.Mutex
+	nextID  uint64
+	backlog []FeedEvent
+	clients map[chan FeedEvent]struct{}
+	closed  bool
+}
+
+// NewEventHub crée un hub sans abonné
+func NewEventHub() *EventHub {
+	return &EventHub{clients: make(map[chan FeedEvent]struct{})}
+}
+
+// Publish numérote l'événement, le conserve et le transmet aux abonnés sans jamais bloquer
+func (h *EventHub) Publish(typ string, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.nextID++
+	ev := FeedEvent{ID: h.nextID, Type: typ, Data: data}
+	h.backlog = append(h.backlog, ev)
+	if len(h.backlog) > feedBacklogSize {
+		h.backlog = append(h.backlog[:0:0], h.backlog[len(h.backlog)-feedBacklogSize:]...)
+	}
+
+	for ch := range h.clients {
+		select {
+		case ch <- ev:
+		default:
+			// Abonné trop lent: déconnecté, il reprendra via Last-Event-ID
+			delete(h.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe abonne un client et retourne son canal ainsi que les événements
+// conservés postérieurs à lastID (aucun si lastID vaut 0).
+// Le canal est fermé à la déconnexion du client par le hub
+func (h *EventHub) Subscribe(lastID uint64) (chan FeedEvent, []FeedEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan FeedEvent, feedClientBuffer)
+	if h.closed {
+		close(ch)
+		return ch, nil
+	}
+	h.clients[ch] = struct{}{}
+
+	var missed []FeedEvent
+	if lastID > 0 {
+		for _, ev := range h.backlog {
+			if ev.ID > lastID {
+				missed = append(missed, ev)
+			}
+		}
+	}
+	return ch, missed
+}
+
+// Unsubscribe désabonne le client, si le hub ne l'a pas déjà déconnecté
+func (h *EventHub) Unsubscribe(ch chan FeedEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[ch]; ok {
+		delete(h.clients, ch)
+		close(ch)
+	}
+}
+
+// Close déconnecte tous les abonnés; les publications suivantes sont ignorées
+func (h *EventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.clients {
+		delete(h.clients, ch)
+		close(ch)
+	}
+}
+
+// SpawnExpiredEvent représente la fin d'un spawn (mêmes identifiants que SpawnInfo);
+// le mot n'est pas révélé, des combats sur ce spawn pouvant encore être en cours
+type SpawnExpiredEvent struct {
+	ID      string `json:"id"`
+	SpawnID string `json:"spawnId,omitempty"`
+	Rarity  string `json:"rarity"`
+}
+
+// CaptureEvent représente la capture d'un mot par un joueur, avec son XP et son niveau après capture
+type CaptureEvent struct {
+	PlayerID    string `json:"playerId"`
+	PlayerName  string `json:"playerName"`
+	Word        string `json:"word"`
+	Rarity      string `json:"rarity"`
+	XP          int    `json:"xp"`
+	PlayerXP    int    `json:"playerXp"`
+	PlayerLevel int    `json:"playerLevel"`
+}
+
+// LevelUpEvent représente un niveau franchi par un joueur
+type LevelUpEvent struct {
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	LevelUpInfo
+}
+
+// PublishSpawn diffuse un nouveau spawn puis, à son échéance, sa fin.
+// Un spawn sans échéance prend fin à l'apparition du suivant
+func (h *Handlers) PublishSpawn(spawn core.SpawnEvent) {
+	h.feedMu.Lock()
+	previous := h.openSpawn
+	h.openSpawn = nil
+	if spawn.ExpiresAt.IsZero() {
+		h.openSpawn = &spawn
+	}
+	h.feedMu.Unlock()
+	if previous != nil {
+		h.feed.Publish(FeedSpawnExpired, newSpawnExpiredEvent(*previous))
+	}
+
+	h.feed.Publish(FeedSpawn, newSpawnInfo(spawn, h.rules.Capture.ModeFor(spawn.Word.Rarity)))
+	if !spawn.ExpiresAt.IsZero() {
+		time.AfterFunc(time.Until(spawn.ExpiresAt), func() {
+			h.feed.Publish(FeedSpawnExpired, newSpawnExpiredEvent(spawn))
+		})
+	}
+}
+
+// publishCapture diffuse la capture et les niveaux franchis par le joueur
+func (h *Handlers) publishCapture(player *PlayerResponse, word core.Word, ups []core.LevelUp) {
+	h.feed.Publish(FeedCapture, CaptureEvent{
+		PlayerID:    player.ID,
+		PlayerName:  player.Name,
+		Word:        word.Text,
+		Rarity:      string(word.Rarity),
+		XP:          word.Points,
+		PlayerXP:    player.XP,
+		PlayerLevel: player.Level,
+	})
+	for _, up := range newLevelUpInfos(ups) {
+		h.feed.Publish(FeedLevelUp, LevelUpEvent{PlayerID: player.ID, PlayerName: player.Name, LevelUpInfo: up})
+	}
+}
+
+// newSpawnExpiredEvent construit l'événement de fin d'un spawn
+func newSpawnExpiredEvent(spawn core.SpawnEvent) SpawnExpiredEvent {
+	return SpawnExpiredEvent{
+		ID:      spawn.Word.ID,
+		SpawnID: spawn.ID,
+		Rarity:  string(spawn.Word.Rarity),
+	}
+}
+
+// StreamEvents diffuse les événements du jeu en Server-Sent Events.
+// L'en-tête Last-Event-ID (ou le paramètre lastEventId) reprend le flux après
+// le dernier événement reçu, tant qu'il est encore conservé
+func (h *Handlers) StreamEvents(c *gin.Context) {
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("lastEventId")
+	}
+	var since uint64
+	if lastID != "" {
+		id, err := strconv.ParseUint(lastID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Last-Event-ID invalide",
+			})
+			return
+		}
+		since = id
+	}
+
+	ch, missed := h.feed.Subscribe(since)
+	defer h.feed.Unsubscribe(ch)
+
+	// Le flux dure au-delà du délai d'écriture du serveur
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, ev := range missed {
+		if !writeFeedEvent(c, ev) {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(feedHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				// Client trop lent ou serveur arrêté
+				return
+			}
+			if !writeFeedEvent(c, ev) {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeFeedEvent écrit un événement au format SSE; retourne false si le client est parti
+func writeFeedEvent(c *gin.Context, ev FeedEvent) bool {
+	data, err := json.Marshal(ev.Data)
+	if err != nil {
+		log.Printf("[feed] encodage de l'événement %s impossible: %v", ev.Type, err)
+		return true
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+	return err == nil
+}
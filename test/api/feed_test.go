@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventHub_DropsSlowClient(t *testing.T) {
+	hub := NewEventHub()
+	slow, _ := hub.Subscribe(0)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i <= feedClientBuffer; i++ {
+			hub.Publish(FeedSpawn, i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish bloqué par un abonné qui ne lit pas")
+	}
+
+	received := 0
+	for range slow {
+		received++
+	}
+	if received != feedClientBuffer {
+		t.Errorf("%d événements reçus avant déconnexion, attendu %d", received, feedClientBuffer)
+	}
+	// Désabonner un client déjà déconnecté ne ferme pas son canal une seconde fois
+	hub.Unsubscribe(slow)
+}
+
+func TestEventHub_Replay(t *testing.T) {
+	hub := NewEventHub()
+	for i := 0; i < 3; i++ {
+		hub.Publish(FeedCapture, i)
+	}
+
+	if _, missed := hub.Subscribe(0); len(missed) != 0 {
+		t.Errorf("sans Last-Event-ID: %d événements rejoués, attendu 0", len(missed))
+	}
+	_, missed := hub.Subscribe(1)
+	if len(missed) != 2 || missed[0].ID != 2 || missed[1].ID != 3 {
+		t.Errorf("reprise après 1: %+v, attendu les événements 2 et 3", missed)
+	}
+
+	for i := 0; i < feedBacklogSize; i++ {
+		hub.Publish(FeedCapture, i)
+	}
+	_, missed = hub.Subscribe(1)
+	if len(missed) != feedBacklogSize || missed[0].ID != 4 {
+		t.Errorf("reprise après 1: %d événements à partir de %d, attendu %d à partir de 4",
+			len(missed), missed[0].ID, feedBacklogSize)
+	}
+}
+
+func TestEventHub_Close(t *testing.T) {
+	hub := NewEventHub()
+	ch, _ := hub.Subscribe(0)
+	hub.Close()
+
+	if _, ok := <-ch; ok {
+		t.Error("canal encore ouvert après Close")
+	}
+	hub.Publish(FeedSpawn, nil)
+	late, missed := hub.Subscribe(0)
+	if _, ok := <-late; ok || len(missed) != 0 {
+		t.Error("abonnement après Close: canal fermé et aucun événement attendus")
+	}
+}
+
+func TestStreamEvents_LastEventID(t *testing.T) {
+	s := newTestServer(nil)
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+	for _, word := range []string{"chat", "lune", "code"} {
+		s.handlers.Feed().Publish(FeedCapture, CaptureEvent{Word: word})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, attendu text/event-stream", ct)
+	}
+
+	var ids []string
+	sc := bufio.NewScanner(resp.Body)
+	for len(ids) < 2 && sc.Scan() {
+		if id, ok := strings.CutPrefix(sc.Text(), "id: "); ok {
+			ids = append(ids, id)
+		}
+		if data, ok := strings.CutPrefix(sc.Text(), "data: "); ok && strings.Contains(data, `"chat"`) {
+			t.Errorf("événement 1 rejoué malgré Last-Event-ID: %s", data)
+		}
+	}
+	if strings.Join(ids, ",") != "2,3" {
+		t.Errorf("événements rejoués = %v, attendu [2 3]", ids)
+	}
+
+	// La fermeture du hub (arrêt du serveur) termine le flux
+	s.handlers.Feed().Close()
+	for sc.Scan() {
+	}
+	if ctx.Err() != nil {
+		t.Error("flux toujours ouvert après la fermeture du hub")
+	}
+
+	w := httptest.NewRecorder()
+	bad := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+	bad.Header.Set("Last-Event-ID", "abc")
+	s.router.ServeHTTP(w, bad)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Last-Event-ID invalide: status %d, attendu 400", w.Code)
+	}
+}
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/jusgaga/wordmon-go/internal/core"
 )
 
@@ -15,14 +19,35 @@ type Handlers struct {
 	playerStore PlayerStore
 	spawnStore  SpawnStore
 	spawner     chan core.SpawnEvent
+	captures    CaptureService
+	tokens      *TokenSigner
+	rules       *core.Rules
+	encounters  *core.EncounterManager
+	feed        *EventHub
+
+	// WebSocket: upgrader, connexions ouvertes et budget des tentatives
+	upgrader       websocket.Upgrader
+	sockets        socketSet
+	attemptLimiter *RateLimiter
+
+	// Spawn sans échéance diffusé en dernier, à clore au spawn suivant
+	feedMu    sync.Mutex
+	openSpawn *core.SpawnEvent
 }
 
-// NewHandlers crée une nouvelle instance de Handlers
+// NewHandlers crée une nouvelle instance de Handlers; les captures passent par
+// le store des joueurs s'il implémente CaptureService (SimpleStore, SQLStore)
 func NewHandlers(playerStore PlayerStore, spawnStore SpawnStore) *Handlers {
+	rules := core.DefaultRules()
+	captures, _ := playerStore.(CaptureService)
 	return &Handlers{
 		playerStore: playerStore,
 		spawnStore:  spawnStore,
+		captures:    captures,
 		spawner:     make(chan core.SpawnEvent, 1),
+		rules:       rules,
+		encounters:  core.NewEncounterManager(rules),
+		feed:        NewEventHub(),
 	}
 }
 
@@ -31,19 +56,48 @@ func (h *Handlers) SetSpawner(spawner chan core.SpawnEvent) {
 	h.spawner = spawner
 }
 
+// SetCaptureService définit le service d'enregistrement des captures
+func (h *Handlers) SetCaptureService(captures CaptureService) {
+	h.captures = captures
+}
+
+// SetTokenSigner active l'authentification: CreatePlayer émet un jeton et
+// RequireAuth l'exige (nil désactive l'authentification)
+func (h *Handlers) SetTokenSigner(tokens *TokenSigner) {
+	h.tokens = tokens
+}
+
+// SetAttemptLimiter définit le limiteur des tentatives soumises par WebSocket
+// (nil: aucune limite); il est partagé avec les routes HTTP de tentative
+func (h *Handlers) SetAttemptLimiter(limiter *RateLimiter) {
+	h.attemptLimiter = limiter
+}
+
+// SetRules définit les règles de jeu (budget de tentatives, défis) des Handlers
+// et recrée le gestionnaire de rencontres; à appeler avant de servir des requêtes
+func (h *Handlers) SetRules(rules *core.Rules) {
+	h.rules = rules
+	h.encounters = core.NewEncounterManager(rules)
+}
+
+// Feed retourne le hub des événements diffusés par GET /api/events
+func (h *Handlers) Feed() *EventHub {
+	return h.feed
+}
+
+// Encounters retourne le gestionnaire des rencontres en cours
+func (h *Handlers) Encounters() *core.EncounterManager {
+	return h.encounters
+}
+
 // GetStatus retourne le statut du serveur
 func (h *Handlers) GetStatus(c *gin.Context) {
 	uptime := time.Since(h.playerStore.GetStartTime()).Seconds()
 
 	var currentSpawn *SpawnInfo
 	if spawn := h.spawnStore.GetCurrentSpawn(); spawn != nil {
-		if spawnEvent, ok := spawn.(core.SpawnEvent); ok {
-			currentSpawn = &SpawnInfo{
-				ID:     spawnEvent.Word.ID,
-				Text:   spawnEvent.Word.Text,
-				Rarity: string(spawnEvent.Word.Rarity),
-				Points: spawnEvent.Word.Points,
-			}
+		if spawnEvent, ok := spawn.(core.SpawnEvent); ok && h.spawnActive(spawnEvent) {
+			currentSpawn = newSpawnInfo(spawnEvent, h.rules.Capture.ModeFor(spawnEvent.Word.Rarity))
 		}
 	}
 
@@ -87,12 +141,22 @@ func (h *Handlers) CreatePlayer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, player)
+	// Jeton de session quand l'authentification est activée
+	response := CreatePlayerResponse{PlayerResponse: player}
+	if h.tokens != nil {
+		token, expiresAt := h.tokens.Issue(player.ID)
+		response.Token = token
+		response.ExpiresAt = &expiresAt
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // GetPlayer retourne les informations d'un joueur
 func (h *Handlers) GetPlayer(c *gin.Context) {
 	playerID := c.Param("id")
+	if !h.authorize(c, playerID) {
+		return
+	}
 
 	player, err := h.playerStore.GetPlayer(playerID)
 	if err != nil {
@@ -103,7 +167,12 @@ func (h *Handlers) GetPlayer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, player)
+	// XP requise pour le niveau suivant (absente au niveau maximal)
+	response := *player
+	if next, ok := core.NextLevelXP(h.rules.LevelCurve(), player.XP); ok {
+		response.NextLevelXP = next
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // GetCurrentSpawn retourne le spawn actuel
@@ -119,13 +188,21 @@ func (h *Handlers) GetCurrentSpawn(c *gin.Context) {
 
 	// Convertir le spawn en SpawnInfo
 	if spawnEvent, ok := spawn.(core.SpawnEvent); ok {
-		spawnInfo := &SpawnInfo{
-			ID:     spawnEvent.Word.ID,
-			Text:   spawnEvent.Word.Text,
-			Rarity: string(spawnEvent.Word.Rarity),
-			Points: spawnEvent.Word.Points,
+		if spawnEvent.Expired(time.Now()) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "no_spawn",
+				Message: "Aucun WordMon actif (le dernier s'est enfui)",
+			})
+			return
+		}
+		if !h.spawnActive(spawnEvent) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "no_spawn",
+				Message: "Aucun WordMon actif (le dernier a été capturé)",
+			})
+			return
 		}
-		c.JSON(http.StatusOK, spawnInfo)
+		c.JSON(http.StatusOK, newSpawnInfo(spawnEvent, h.rules.Capture.ModeFor(spawnEvent.Word.Rarity)))
 	} else {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "invalid_spawn",
@@ -134,7 +211,80 @@ func (h *Handlers) GetCurrentSpawn(c *gin.Context) {
 	}
 }
 
-// AttemptCapture tente de capturer un WordMon
+// newSpawnInfo convertit un spawn en SpawnInfo, avec son mode de capture et sans le mot
+func newSpawnInfo(spawn core.SpawnEvent, mode core.CaptureMode) *SpawnInfo {
+	info := &SpawnInfo{
+		ID:      spawn.Word.ID,
+		SpawnID: spawn.ID,
+		Length:  len([]rune(spawn.Word.Text)),
+		Rarity:  string(spawn.Word.Rarity),
+		Points:  spawn.Word.Points,
+		Mode:    string(mode),
+	}
+	if !spawn.ExpiresAt.IsZero() {
+		expiresAt := spawn.ExpiresAt
+		info.ExpiresAt = &expiresAt
+	}
+	return info
+}
+
+// toCorePlayer convertit un joueur du store en joueur core, sans partager ses maps ni ses slices
+func toCorePlayer(player *PlayerResponse) core.Player {
+	p := core.Player{
+		ID:        player.ID,
+		Name:      player.Name,
+		XP:        player.XP,
+		Level:     player.Level,
+		Inventory: copyCounts(player.Inventory),
+		Items:     copyCounts(player.Items),
+		Titles:    append([]string(nil), player.Titles...),
+	}
+	if p.Inventory == nil {
+		p.Inventory = make(map[string]int)
+	}
+	for _, r := range player.UnlockedRarities {
+		p.Unlocked = append(p.Unlocked, core.Rarity(r))
+	}
+	return p
+}
+
+// applyCorePlayer recopie XP, niveau, inventaire et récompenses dans le joueur du store
+func applyCorePlayer(player *PlayerResponse, p core.Player) {
+	player.XP, player.Level = p.XP, p.Level
+	player.Inventory = p.Inventory
+	player.Items, player.Titles = p.Items, p.Titles
+	player.UnlockedRarities = nil
+	for _, r := range p.Unlocked {
+		player.UnlockedRarities = append(player.UnlockedRarities, string(r))
+	}
+}
+
+// copyCounts retourne une copie d'un inventaire (nil reste nil)
+func copyCounts(counts map[string]int) map[string]int {
+	if counts == nil {
+		return nil
+	}
+	out := make(map[string]int, len(counts))
+	for k, v := range counts {
+		out[k] = v
+	}
+	return out
+}
+
+// newLevelUpInfos convertit les montées de niveau core pour la réponse
+func newLevelUpInfos(ups []core.LevelUp) []LevelUpInfo {
+	infos := make([]LevelUpInfo, 0, len(ups))
+	for _, up := range ups {
+		info := LevelUpInfo{Level: up.Level, Items: up.Reward.Items, Title: up.Reward.Title}
+		for _, r := range up.Reward.UnlockRarities {
+			info.UnlockedRarities = append(info.UnlockedRarities, string(r))
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// AttemptCapture tente de capturer un WordMon via la rencontre du joueur
 func (h *Handlers) AttemptCapture(c *gin.Context) {
 	var req CaptureAttemptRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -144,6 +294,9 @@ func (h *Handlers) AttemptCapture(c *gin.Context) {
 		})
 		return
 	}
+	if !h.authorize(c, req.PlayerID) {
+		return
+	}
 
 	// Vérifier que le joueur existe
 	player, err := h.playerStore.GetPlayer(req.PlayerID)
@@ -175,32 +328,420 @@ func (h *Handlers) AttemptCapture(c *gin.Context) {
 		return
 	}
 
-	// Simuler la logique de capture
-	// Pour l'instant, on considère que c'est une capture réussie si l'essai correspond
-	if req.Attempt == spawnEvent.Word.Text {
-		// Capture réussie
-		player.XP += spawnEvent.Word.Points
-		player.Level = 1 + player.XP/100
-		player.Inventory[spawnEvent.Word.Text]++
+	o := h.attemptSpawn(player, spawnEvent, req.Attempt)
+	c.JSON(o.status, o.body)
+}
 
-		// Mettre à jour le joueur dans le store
-		h.playerStore.UpdatePlayer(player)
+// outcome est une réponse calculée indépendamment du transport (HTTP ou WebSocket)
+type outcome struct {
+	status int
+	body   any
+}
 
-		c.JSON(http.StatusOK, CaptureResultResponse{
-			Status:   "captured",
-			Word:     spawnEvent.Word.Text,
-			Rarity:   string(spawnEvent.Word.Rarity),
-			XP:       spawnEvent.Word.Points,
-			NewLevel: player.Level,
-		})
-	} else {
-		// Capture échouée
-		c.JSON(http.StatusOK, CaptureResultResponse{
+// attemptSpawn engage le joueur face au spawn et soumet la tentative
+func (h *Handlers) attemptSpawn(player *PlayerResponse, spawnEvent core.SpawnEvent, attempt string) outcome {
+	// Spawn déjà remporté par un autre joueur en mode course
+	if winner, ok := h.claimedBy(spawnEvent.ID, spawnEvent.Word.Rarity, player.ID); ok {
+		encounterID := ""
+		if info, found := h.encounters.Get(player.ID); found && info.SpawnID == spawnEvent.ID {
+			encounterID = info.ID
+		}
+		return h.claimed(encounterID, spawnEvent.Word, winner)
+	}
+
+	p := toCorePlayer(player)
+	info, err := h.encounters.Engage(&p, spawnEvent)
+	if o, failed := encounterError(err); failed {
+		return o
+	}
+	if o, done := finished(info); done {
+		return o
+	}
+	return h.submitAttempt(player, info, attempt)
+}
+
+// claimedBy retourne le joueur ayant remporté un spawn disputé, s'il s'agit d'un autre joueur
+func (h *Handlers) claimedBy(spawnID string, rarity core.Rarity, playerID string) (string, bool) {
+	if spawnID == "" || h.rules.Capture.ModeFor(rarity) != core.CaptureRace {
+		return "", false
+	}
+	winner, ok := h.spawnStore.SpawnWinner(spawnID)
+	if !ok || winner == playerID {
+		return "", false
+	}
+	return winner, true
+}
+
+// claimed met fin à la rencontre (si encounterID n'est pas vide) et répond
+// que le mot a été capturé par le gagnant de la course
+func (h *Handlers) claimed(encounterID string, word core.Word, winnerID string) outcome {
+	if encounterID != "" {
+		_, _ = h.encounters.ForfeitByID(encounterID)
+	}
+	capturedBy := h.playerName(winnerID)
+	return outcome{http.StatusOK, CaptureResultResponse{
+		Status:     "already_captured",
+		Word:       word.Text,
+		Rarity:     string(word.Rarity),
+		CapturedBy: capturedBy,
+		Reason:     "captured by " + capturedBy,
+	}}
+}
+
+// playerName retourne le nom du joueur, ou son identifiant s'il est introuvable
+func (h *Handlers) playerName(id string) string {
+	if player, err := h.playerStore.GetPlayer(id); err == nil {
+		return player.Name
+	}
+	return id
+}
+
+// spawnActive indique si le spawn peut encore être capturé (ni enfui, ni remporté en mode course)
+func (h *Handlers) spawnActive(spawn core.SpawnEvent) bool {
+	if spawn.Expired(time.Now()) {
+		return false
+	}
+	_, claimed := h.claimedBy(spawn.ID, spawn.Word.Rarity, "")
+	return !claimed
+}
+
+// encounterError traduit une erreur de rencontre en réponse; retourne false si err est nil
+func encounterError(err error) (outcome, bool) {
+	if err == nil {
+		return outcome{}, false
+	}
+	var expired *core.ExpiredError
+	var notFound *core.EncounterNotFoundError
+	switch {
+	case errors.As(err, &expired):
+		// Le WordMon s'est enfui après son échéance
+		return outcome{http.StatusOK, CaptureResultResponse{
+			Status: "expired",
+			Word:   expired.Word,
+			Reason: expired.Error(),
+		}}, true
+	case errors.As(err, &notFound):
+		return outcome{http.StatusNotFound, ErrorResponse{
+			Error:   "encounter_not_found",
+			Message: "Rencontre introuvable ou terminée",
+		}}, true
+	default:
+		return outcome{http.StatusInternalServerError, ErrorResponse{
+			Error:   "encounter_error",
+			Message: err.Error(),
+		}}, true
+	}
+}
+
+// finished retourne la réponse d'un combat déjà terminé pour ce spawn, le cas échéant
+func finished(info core.EncounterInfo) (outcome, bool) {
+	switch info.Phase {
+	case core.StateCaptured:
+		return outcome{http.StatusOK, CaptureResultResponse{
+			Status: "already_captured",
+			Word:   info.Word.Text,
+			Reason: "already captured",
+		}}, true
+	case core.StateFled:
+		return outcome{http.StatusOK, CaptureResultResponse{
 			Status: "fled",
-			Word:   spawnEvent.Word.Text,
-			Reason: "wrong attempt",
+			Word:   info.Word.Text,
+			Reason: "no attempts left",
+		}}, true
+	default:
+		return outcome{}, false
+	}
+}
+
+// submitAttempt soumet la tentative à la rencontre et résout le combat s'il est terminé
+func (h *Handlers) submitAttempt(player *PlayerResponse, encounter core.EncounterInfo, attempt string) outcome {
+	encounterID := encounter.ID
+	if winner, ok := h.claimedBy(encounter.SpawnID, encounter.Word.Rarity, player.ID); ok {
+		return h.claimed(encounterID, encounter.Word, winner)
+	}
+
+	fb, info, err := h.encounters.AttemptByID(encounterID, attempt)
+	var expired *core.ExpiredError
+	var notFound *core.EncounterNotFoundError
+	if err != nil && (errors.As(err, &expired) || errors.As(err, &notFound)) {
+		o, _ := encounterError(err)
+		return o
+	}
+	if err != nil {
+		// Tentative refusée par le défi (vide, trop courte...): non décomptée
+		return outcome{http.StatusOK, CaptureResultResponse{
+			Status:            "invalid",
+			Challenge:         info.ChallengeName,
+			Instructions:      info.Instructions,
+			Reason:            err.Error(),
+			RemainingAttempts: info.AttemptsLeft,
+		}}
+	}
+
+	if fb.Correct {
+		// En mode course, seul le premier à réserver le spawn capture le mot
+		race := h.rules.Capture.ModeFor(info.Word.Rarity) == core.CaptureRace && info.SpawnID != ""
+		if race {
+			winner, err := h.spawnStore.ClaimSpawn(info.SpawnID, player.ID)
+			if err != nil {
+				return outcome{http.StatusInternalServerError, ErrorResponse{
+					Error:   "claim_error",
+					Message: err.Error(),
+				}}
+			}
+			if winner != player.ID {
+				return h.claimed(encounterID, info.Word, winner)
+			}
+		}
+
+		// La capture (inventaire, XP, niveau, récompenses) est d'abord enregistrée
+		// en une seule opération par le store; en cas d'échec, la réservation est
+		// libérée et la rencontre abandonnée, sans capture journalisée
+		result, err := h.capture(player.ID, info.Word)
+		if err != nil {
+			if race {
+				if relErr := h.spawnStore.ReleaseSpawn(info.SpawnID, player.ID); relErr != nil {
+					log.Printf("[capture] libération du spawn %s impossible: %v", info.SpawnID, relErr)
+				}
+			}
+			_, _ = h.encounters.ForfeitByID(encounterID)
+		}
+		var conflict *ConflictError
+		if errors.As(err, &conflict) {
+			return outcome{http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: err.Error(),
+			}}
+		}
+		if err != nil {
+			return outcome{http.StatusInternalServerError, ErrorResponse{
+				Error:   "capture_error",
+				Message: err.Error(),
+			}}
+		}
+
+		// Capture enregistrée: la rencontre passe à CAPTURED (sur une copie du
+		// joueur) et la capture est journalisée
+		p := toCorePlayer(player)
+		if resolved, err := h.encounters.ResolveByID(encounterID, &p); err != nil {
+			log.Printf("[capture] rencontre %s non clôturée après capture: %v", encounterID, err)
+		} else {
+			info = resolved
+		}
+		h.publishCapture(result.Player, info.Word, result.LevelUps)
+
+		return outcome{http.StatusOK, CaptureResultResponse{
+			Status:            "captured",
+			Word:              info.Word.Text,
+			Rarity:            string(info.Word.Rarity),
+			Challenge:         info.ChallengeName,
+			XP:                info.Word.Points,
+			NewLevel:          result.Player.Level,
+			RemainingAttempts: fb.Remaining,
+			LevelUps:          newLevelUpInfos(result.LevelUps),
+		}}
+	}
+
+	// Tentative ratée: le combat continue tant qu'il reste des tentatives;
+	// le mot n'est révélé qu'une fois le combat perdu
+	resp := CaptureResultResponse{
+		Status:            "wrong",
+		Challenge:         info.ChallengeName,
+		Instructions:      info.Instructions,
+		Reason:            "wrong attempt",
+		RemainingAttempts: fb.Remaining,
+		Missing:           fb.Missing,
+		Extra:             fb.Extra,
+	}
+	if info.Phase == core.StateLost {
+		p := toCorePlayer(player)
+		_, _ = h.encounters.ResolveByID(encounterID, &p)
+		resp.Status = "fled"
+		resp.Word = info.Word.Text
+		resp.Instructions = ""
+	}
+	return outcome{http.StatusOK, resp}
+}
+
+// capture enregistre la capture via le CaptureService des handlers
+func (h *Handlers) capture(playerID string, word core.Word) (*CaptureResult, error) {
+	if h.captures == nil {
+		return nil, errors.New("aucun service de capture configuré")
+	}
+	return h.captures.Capture(playerID, word, h.rules)
+}
+
+// newEncounterResponse convertit une rencontre en EncounterResponse; le mot
+// n'est révélé qu'une fois le combat terminé
+func newEncounterResponse(info core.EncounterInfo) EncounterResponse {
+	resp := EncounterResponse{
+		ID:                info.ID,
+		PlayerID:          info.PlayerID,
+		Round:             info.Round,
+		State:             string(info.Phase),
+		Rarity:            string(info.Word.Rarity),
+		Points:            info.Word.Points,
+		Challenge:         info.ChallengeName,
+		Instructions:      info.Instructions,
+		RemainingAttempts: info.AttemptsLeft,
+	}
+	if !info.Deadline.IsZero() {
+		deadline := info.Deadline
+		resp.ExpiresAt = &deadline
+	}
+	if info.Phase != core.StateInBattle && info.Phase != core.StateEncounter {
+		resp.Word = info.Word.Text
+	}
+	return resp
+}
+
+// StartEncounter démarre (ou reprend) la rencontre du joueur sur le spawn actuel
+func (h *Handlers) StartEncounter(c *gin.Context) {
+	var req StartEncounterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "playerId requis",
+		})
+		return
+	}
+	if !h.authorize(c, req.PlayerID) {
+		return
+	}
+
+	o := h.startEncounter(req.PlayerID)
+	c.JSON(o.status, o.body)
+}
+
+// startEncounter engage le joueur face au spawn actuel
+func (h *Handlers) startEncounter(playerID string) outcome {
+	player, err := h.playerStore.GetPlayer(playerID)
+	if err != nil {
+		return outcome{http.StatusNotFound, ErrorResponse{
+			Error:   "player_not_found",
+			Message: "Joueur non trouvé",
+		}}
+	}
+
+	spawnEvent, ok := h.spawnStore.GetCurrentSpawn().(core.SpawnEvent)
+	if !ok {
+		return outcome{http.StatusNotFound, ErrorResponse{
+			Error:   "no_spawn",
+			Message: "Aucun WordMon actif",
+		}}
+	}
+
+	if winner, claimed := h.claimedBy(spawnEvent.ID, spawnEvent.Word.Rarity, player.ID); claimed {
+		return outcome{http.StatusConflict, ErrorResponse{
+			Error:   "already_captured",
+			Message: "WordMon déjà capturé par " + h.playerName(winner),
+		}}
+	}
+
+	p := toCorePlayer(player)
+	info, err := h.encounters.Engage(&p, spawnEvent)
+	if o, failed := encounterError(err); failed {
+		return o
+	}
+	return outcome{http.StatusCreated, newEncounterResponse(info)}
+}
+
+// GetEncounter retourne l'état d'une rencontre
+func (h *Handlers) GetEncounter(c *gin.Context) {
+	info, ok := h.encounters.Lookup(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "encounter_not_found",
+			Message: "Rencontre introuvable ou terminée",
+		})
+		return
+	}
+	if !h.authorize(c, info.PlayerID) {
+		return
+	}
+	c.JSON(http.StatusOK, newEncounterResponse(info))
+}
+
+// SubmitEncounterAttempt soumet une tentative dans une rencontre
+func (h *Handlers) SubmitEncounterAttempt(c *gin.Context) {
+	var req EncounterAttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "attempt requis",
+		})
+		return
+	}
+
+	info, ok := h.encounters.Lookup(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "encounter_not_found",
+			Message: "Rencontre introuvable ou terminée",
+		})
+		return
+	}
+	if !h.authorize(c, info.PlayerID) {
+		return
+	}
+
+	o := h.attemptEncounter(info, req.Attempt)
+	c.JSON(o.status, o.body)
+}
+
+// attemptEncounter soumet une tentative dans une rencontre existante
+func (h *Handlers) attemptEncounter(info core.EncounterInfo, attempt string) outcome {
+	if winner, claimed := h.claimedBy(info.SpawnID, info.Word.Rarity, info.PlayerID); claimed {
+		return h.claimed(info.ID, info.Word, winner)
+	}
+	if o, done := finished(info); done {
+		return o
+	}
+
+	player, err := h.playerStore.GetPlayer(info.PlayerID)
+	if err != nil {
+		return outcome{http.StatusNotFound, ErrorResponse{
+			Error:   "player_not_found",
+			Message: "Joueur non trouvé",
+		}}
+	}
+	return h.submitAttempt(player, info, attempt)
+}
+
+// GetPlayerEvents retourne le journal d'événements d'un joueur (enquête sur une partie)
+func (h *Handlers) GetPlayerEvents(c *gin.Context) {
+	playerID := c.Param("id")
+	if !h.authorize(c, playerID) {
+		return
+	}
+
+	if h.rules.Events == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "no_event_log",
+			Message: "Journal d'événements désactivé",
 		})
+		return
+	}
+	if _, err := h.playerStore.GetPlayer(playerID); err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "player_not_found",
+			Message: "Joueur non trouvé",
+		})
+		return
+	}
+
+	events, err := h.rules.Events.Events(playerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "event_log_error",
+			Message: err.Error(),
+		})
+		return
+	}
+	if events == nil {
+		events = []core.Event{}
 	}
+	c.JSON(http.StatusOK, events)
 }
 
 // GetLeaderboard retourne le classement des joueurs
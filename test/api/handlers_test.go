@@ -0,0 +1,388 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// playersOnly masque le CaptureService du store
+type playersOnly struct {
+	PlayerStore
+}
+
+func TestHandlers_CaptureRequiresCaptureService(t *testing.T) {
+	store := NewSimpleStore()
+	h := NewHandlers(playersOnly{store}, store)
+	if h.captures != nil {
+		t.Fatal("le store de test ne doit pas fournir de CaptureService")
+	}
+	player, err := store.CreatePlayer("sacha")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.capture(player.ID, core.Word{ID: "w1", Text: "chat", Rarity: core.Common, Points: 10}); err == nil {
+		t.Fatal("capture devrait échouer sans CaptureService")
+	}
+	if got, _ := store.GetPlayer(player.ID); got.XP != 0 || got.Inventory["chat"] != 0 {
+		t.Errorf("joueur = %+v, aucune capture attendue", got)
+	}
+}
+
+// raceHandlers crée des handlers en mode course avec un spawn "chat" actif
+func raceHandlers(t *testing.T) (*Handlers, *SimpleStore, core.SpawnEvent) {
+	t.Helper()
+	store := NewSimpleStore()
+	h := NewHandlers(store, store)
+	rules := core.DefaultRules()
+	rules.Capture = core.CapturePolicy{Default: core.CaptureRace}
+	h.SetRules(rules)
+	spawn := core.SpawnEvent{
+		ID:        "s1",
+		Word:      core.Word{ID: "w1", Text: "chat", Rarity: core.Common, Points: 10},
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := store.AddSpawn(spawn); err != nil {
+		t.Fatal(err)
+	}
+	return h, store, spawn
+}
+
+func TestSimpleStore_ClaimSpawnConcurrent(t *testing.T) {
+	store := NewSimpleStore()
+	winners := make([]string, 20)
+	var wg sync.WaitGroup
+	for i := range winners {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			winners[i], _ = store.ClaimSpawn("s1", string(rune('a'+i)))
+		}(i)
+	}
+	wg.Wait()
+
+	winner, ok := store.SpawnWinner("s1")
+	if !ok {
+		t.Fatal("le spawn devrait avoir un gagnant")
+	}
+	for i, got := range winners {
+		if got != winner {
+			t.Errorf("joueur %d: gagnant %q, attendu %q pour tous", i, got, winner)
+		}
+	}
+}
+
+func TestHandlers_RaceCaptureConcurrent(t *testing.T) {
+	h, store, spawn := raceHandlers(t)
+	players := make([]*PlayerResponse, 10)
+	for i := range players {
+		players[i], _ = store.CreatePlayer(string(rune('a' + i)))
+	}
+
+	results := make([]CaptureResultResponse, len(players))
+	var wg sync.WaitGroup
+	for i, player := range players {
+		wg.Add(1)
+		go func(i int, player *PlayerResponse) {
+			defer wg.Done()
+			o := h.attemptSpawn(player, spawn, "tach")
+			results[i], _ = o.body.(CaptureResultResponse)
+		}(i, player)
+	}
+	wg.Wait()
+
+	winnerID, ok := store.SpawnWinner(spawn.ID)
+	if !ok {
+		t.Fatal("le spawn devrait avoir un gagnant")
+	}
+	winner, _ := store.GetPlayer(winnerID)
+	captured := 0
+	for i, res := range results {
+		switch {
+		case res.Status == "captured" && players[i].ID == winnerID:
+			captured++
+		case res.Status == "already_captured" && res.CapturedBy == winner.Name:
+		default:
+			t.Errorf("joueur %s: %+v, attendu captured (gagnant) ou already_captured par %s", players[i].Name, res, winner.Name)
+		}
+	}
+	if captured != 1 || winner.Inventory["chat"] != 1 {
+		t.Errorf("%d captures, inventaire du gagnant %v: attendu une seule capture", captured, winner.Inventory)
+	}
+}
+
+func TestHandlers_RaceLoserSeesWinner(t *testing.T) {
+	h, store, spawn := raceHandlers(t)
+	h.rules.Attempts = core.AttemptBudget{Default: 3}
+	sacha, _ := store.CreatePlayer("sacha")
+	ondine, _ := store.CreatePlayer("ondine")
+
+	// ondine engage le combat avant que sacha ne capture le mot
+	if o := h.attemptSpawn(ondine, spawn, "chut"); o.body.(CaptureResultResponse).Status != "wrong" {
+		t.Fatalf("ondine: %+v, attendu wrong", o.body)
+	}
+	if o := h.attemptSpawn(sacha, spawn, "tach"); o.body.(CaptureResultResponse).Status != "captured" {
+		t.Fatalf("sacha: %+v, attendu captured", o.body)
+	}
+
+	o := h.attemptSpawn(ondine, spawn, "tach")
+	res, _ := o.body.(CaptureResultResponse)
+	if o.status != http.StatusOK || res.Status != "already_captured" || res.CapturedBy != "sacha" || res.Reason != "captured by sacha" {
+		t.Errorf("perdante: %d %+v, attendu already_captured par sacha", o.status, o.body)
+	}
+	if got, _ := store.GetPlayer(ondine.ID); got.Inventory["chat"] != 0 {
+		t.Errorf("la perdante a capturé le mot: %v", got.Inventory)
+	}
+	if info, _ := h.encounters.Get(ondine.ID); info.Phase != core.StateFled {
+		t.Errorf("rencontre de la perdante en phase %s, attendu %s", info.Phase, core.StateFled)
+	}
+}
+
+// failingCaptures refuse toutes les captures
+type failingCaptures struct{}
+
+func (failingCaptures) Capture(string, core.Word, *core.Rules) (*CaptureResult, error) {
+	return nil, errors.New("base indisponible")
+}
+
+func TestHandlers_RaceReleasesClaimOnFailedCapture(t *testing.T) {
+	h, store, spawn := raceHandlers(t)
+	h.SetCaptureService(failingCaptures{})
+	sacha, _ := store.CreatePlayer("sacha")
+
+	if o := h.attemptSpawn(sacha, spawn, "tach"); o.status != http.StatusInternalServerError {
+		t.Fatalf("capture en échec: %d %+v, attendu 500", o.status, o.body)
+	}
+	if winner, ok := store.SpawnWinner(spawn.ID); ok {
+		t.Fatalf("réservation conservée pour %s malgré l'échec de la capture", winner)
+	}
+
+	// Le spawn reste disputable: un autre joueur peut le capturer
+	h.SetCaptureService(store)
+	ondine, _ := store.CreatePlayer("ondine")
+	if o := h.attemptSpawn(ondine, spawn, "tach"); o.body.(CaptureResultResponse).Status != "captured" {
+		t.Errorf("ondine: %+v, attendu captured après libération", o.body)
+	}
+}
+
+const testAddr = "192.0.2.1:1234"
+
+// encounterServer crée un serveur dont l'horloge est clock, avec le joueur
+// sacha et un spawn "chat" (3 tentatives) qui s'enfuit au bout d'une minute
+func encounterServer(t *testing.T, clock *core.FakeClock) (*Server, *PlayerResponse) {
+	t.Helper()
+	s := newTestServer(nil)
+	rules := core.DefaultRules()
+	rules.Attempts = core.AttemptBudget{Default: 3}
+	rules.Clock = clock
+	s.SetRules(rules)
+	player, err := s.handlers.playerStore.CreatePlayer("sacha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spawn := core.SpawnEvent{
+		ID:        "s1",
+		Round:     1,
+		Word:      core.Word{ID: "w1", Text: "chat", Rarity: core.Common, Points: 10},
+		ExpiresAt: clock.Now().Add(time.Minute),
+	}
+	if err := s.handlers.spawnStore.AddSpawn(spawn); err != nil {
+		t.Fatal(err)
+	}
+	return s, player
+}
+
+// decode lit la réponse JSON dans v et vérifie le code HTTP
+func decode(t *testing.T, w *httptest.ResponseRecorder, status int, v any) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("code = %d, attendu %d: %s", w.Code, status, w.Body)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("réponse illisible %s: %v", w.Body, err)
+	}
+}
+
+func TestHandlers_StartEncounter(t *testing.T) {
+	s, player := encounterServer(t, core.NewFakeClock(time.Now()))
+
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  int
+		wantError string
+	}{
+		{"corps invalide", `{}`, http.StatusBadRequest, "invalid_request"},
+		{"joueur inconnu", `{"playerId":"absent"}`, http.StatusNotFound, "player_not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", tt.body), tt.wantCode, &resp)
+			if resp.Error != tt.wantError {
+				t.Errorf("erreur = %q, attendu %q", resp.Error, tt.wantError)
+			}
+		})
+	}
+
+	body := `{"playerId":"` + player.ID + `"}`
+	var enc EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", body), http.StatusCreated, &enc)
+	if enc.ID == "" || enc.PlayerID != player.ID || enc.State != string(core.StateInBattle) || enc.RemainingAttempts != 3 || enc.Word != "" || enc.ExpiresAt == nil {
+		t.Errorf("rencontre = %+v, attendu un combat en cours de 3 tentatives, mot caché", enc)
+	}
+
+	// Reprise: la même rencontre est retournée
+	var again EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", body), http.StatusCreated, &again)
+	if again.ID != enc.ID {
+		t.Errorf("reprise: rencontre %s, attendu %s", again.ID, enc.ID)
+	}
+
+	var got EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodGet, "/api/encounters/"+enc.ID, ""), http.StatusOK, &got)
+	if got.ID != enc.ID || got.RemainingAttempts != 3 || got.Word != "" {
+		t.Errorf("GET = %+v, attendu la rencontre %s", got, enc.ID)
+	}
+
+	var notFound ErrorResponse
+	decode(t, serveFrom(s, testAddr, http.MethodGet, "/api/encounters/absente", ""), http.StatusNotFound, &notFound)
+	if notFound.Error != "encounter_not_found" {
+		t.Errorf("GET inconnue: %+v, attendu encounter_not_found", notFound)
+	}
+
+	// Sans spawn actif
+	empty := newTestServer(nil)
+	other, _ := empty.handlers.playerStore.CreatePlayer("ondine")
+	var noSpawn ErrorResponse
+	decode(t, serveFrom(empty, testAddr, http.MethodPost, "/api/encounters", `{"playerId":"`+other.ID+`"}`), http.StatusNotFound, &noSpawn)
+	if noSpawn.Error != "no_spawn" {
+		t.Errorf("sans spawn: %+v, attendu no_spawn", noSpawn)
+	}
+}
+
+func TestHandlers_SubmitEncounterAttempt(t *testing.T) {
+	s, player := encounterServer(t, core.NewFakeClock(time.Now()))
+	var enc EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", `{"playerId":"`+player.ID+`"}`), http.StatusCreated, &enc)
+	path := "/api/encounters/" + enc.ID + "/attempts"
+
+	var badRequest ErrorResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, path, `{}`), http.StatusBadRequest, &badRequest)
+	var notFound ErrorResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters/absente/attempts", `{"attempt":"tach"}`), http.StatusNotFound, &notFound)
+	if badRequest.Error != "invalid_request" || notFound.Error != "encounter_not_found" {
+		t.Errorf("erreurs = %q, %q, attendu invalid_request, encounter_not_found", badRequest.Error, notFound.Error)
+	}
+
+	// Tentatives épuisées: le mot s'enfuit et n'est révélé qu'à ce moment
+	for i, want := range []struct {
+		status    string
+		remaining int
+	}{{"wrong", 2}, {"wrong", 1}, {"fled", 0}} {
+		var res CaptureResultResponse
+		decode(t, serveFrom(s, testAddr, http.MethodPost, path, `{"attempt":"chut"}`), http.StatusOK, &res)
+		if res.Status != want.status || res.RemainingAttempts != want.remaining || (res.Word != "") != (want.status == "fled") {
+			t.Errorf("tentative %d: %+v, attendu %s avec %d tentatives", i+1, res, want.status, want.remaining)
+		}
+	}
+
+	var res CaptureResultResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, path, `{"attempt":"tach"}`), http.StatusOK, &res)
+	if res.Status != "fled" || res.Word != "chat" {
+		t.Errorf("après fuite: %+v, attendu fled", res)
+	}
+	var got EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodGet, "/api/encounters/"+enc.ID, ""), http.StatusOK, &got)
+	if got.State != string(core.StateFled) || got.Word != "chat" {
+		t.Errorf("GET après fuite = %+v, attendu FLED et mot révélé", got)
+	}
+	if p, _ := s.handlers.playerStore.GetPlayer(player.ID); p.XP != 0 || p.Inventory["chat"] != 0 {
+		t.Errorf("joueur = %+v, attendu aucune capture", p)
+	}
+}
+
+func TestHandlers_EncounterCapture(t *testing.T) {
+	s, player := encounterServer(t, core.NewFakeClock(time.Now()))
+	var enc EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", `{"playerId":"`+player.ID+`"}`), http.StatusCreated, &enc)
+	path := "/api/encounters/" + enc.ID + "/attempts"
+
+	var res CaptureResultResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, path, `{"attempt":"tach"}`), http.StatusOK, &res)
+	if res.Status != "captured" || res.Word != "chat" || res.XP != 10 || res.RemainingAttempts != 3 {
+		t.Errorf("capture = %+v, attendu captured (+10 XP, tentative réussie non décomptée)", res)
+	}
+	decode(t, serveFrom(s, testAddr, http.MethodPost, path, `{"attempt":"tach"}`), http.StatusOK, &res)
+	if res.Status != "already_captured" {
+		t.Errorf("seconde tentative: %+v, attendu already_captured", res)
+	}
+	if p, _ := s.handlers.playerStore.GetPlayer(player.ID); p.XP != 10 || p.Inventory["chat"] != 1 {
+		t.Errorf("joueur = %+v, attendu une seule capture", p)
+	}
+}
+
+func TestHandlers_EncounterExpired(t *testing.T) {
+	clock := core.NewFakeClock(time.Now())
+	s, player := encounterServer(t, clock)
+	var enc EncounterResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters", `{"playerId":"`+player.ID+`"}`), http.StatusCreated, &enc)
+
+	clock.Advance(2 * time.Minute)
+	var res CaptureResultResponse
+	decode(t, serveFrom(s, testAddr, http.MethodPost, "/api/encounters/"+enc.ID+"/attempts", `{"attempt":"tach"}`), http.StatusOK, &res)
+	if res.Status != "expired" || res.Word != "chat" {
+		t.Errorf("après échéance: %+v, attendu expired", res)
+	}
+	if p, _ := s.handlers.playerStore.GetPlayer(player.ID); p.Inventory["chat"] != 0 {
+		t.Errorf("mot capturé après sa fuite: %v", p.Inventory)
+	}
+}
+
+func TestHandlers_AttemptCapture(t *testing.T) {
+	clock := core.NewFakeClock(time.Now())
+	s, player := encounterServer(t, clock)
+	attempt := func(word string) string {
+		return `{"playerId":"` + player.ID + `","attempt":"` + word + `"}`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   int
+		wantStatus string
+	}{
+		{"joueur inconnu", `{"playerId":"absent","attempt":"tach"}`, http.StatusNotFound, ""},
+		{"mauvaise tentative", attempt("chut"), http.StatusOK, "wrong"},
+		{"capture", attempt("tach"), http.StatusOK, "captured"},
+		{"déjà capturé", attempt("tach"), http.StatusOK, "already_captured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveFrom(s, testAddr, http.MethodPost, "/api/encounter/attempt", tt.body)
+			var res CaptureResultResponse
+			decode(t, w, tt.wantCode, &res)
+			if res.Status != tt.wantStatus {
+				t.Errorf("statut = %q, attendu %q: %s", res.Status, tt.wantStatus, w.Body)
+			}
+		})
+	}
+
+	// Spawn enfui avant le premier combat du joueur
+	ondine, _ := s.handlers.playerStore.CreatePlayer("ondine")
+	clock.Advance(2 * time.Minute)
+	w := serveFrom(s, testAddr, http.MethodPost, "/api/encounter/attempt", `{"playerId":"`+ondine.ID+`","attempt":"tach"}`)
+	var res CaptureResultResponse
+	decode(t, w, http.StatusOK, &res)
+	if res.Status != "expired" || strings.Contains(w.Body.String(), "captured") {
+		t.Errorf("spawn enfui: %s, attendu expired", w.Body)
+	}
+}
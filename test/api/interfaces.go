@@ -14,13 +14,17 @@ type PlayerStore interface {
 	UpdatePlayer(player *PlayerResponse) error
 	GetPlayerCount() int
 	GetStartTime() time.Time
-	UpdateXP(id string, newXP int, newLevel int) error
 }
 
 // SpawnStore définit l'interface pour le stockage des spawns
 type SpawnStore interface {
 	AddSpawn(spawn interface{}) error
 	GetCurrentSpawn() interface{}
+	// ClaimSpawn réserve atomiquement le spawn au premier joueur et retourne le gagnant
+	ClaimSpawn(spawnID, playerID string) (string, error)
+	// ReleaseSpawn annule la réservation du joueur (capture non enregistrée)
+	ReleaseSpawn(spawnID, playerID string) error
+	SpawnWinner(spawnID string) (string, bool)
 }
 
 // WordStore définit l'interface pour le stockage des mots
@@ -36,6 +40,12 @@ type CaptureStore interface {
 	ListByPlayer(playerId string) ([]core.Word, error)
 }
 
+// CaptureService enregistre une capture en une seule opération atomique:
+// inventaire, XP, niveau et récompenses du joueur selon les règles fournies
+type CaptureService interface {
+	Capture(playerID string, word core.Word, rules *core.Rules) (*CaptureResult, error)
+}
+
 // LeaderboardStore définit l'interface pour le leaderboard
 type LeaderboardStore interface {
 	GetLeaderboard(limit int) ([]*PlayerResponse, error)
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jusgaga/wordmon-go/internal/config"
+)
+
+// corsMiddleware ajoute les en-têtes CORS des origines autorisées et répond aux requêtes préliminaires
+func corsMiddleware(cfg *config.APIConfig) gin.HandlerFunc {
+	cors := cfg.API.CORS
+	allowAll := false
+	origins := make(map[string]bool, len(cors.AllowedOrigins))
+	for _, o := range cors.AllowedOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		origins[o] = true
+	}
+	methods := strings.Join(cors.AllowedMethods, ", ")
+	headers := strings.Join(cors.AllowedHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && (allowAll || origins[origin]) {
+			if allowAll {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Vary", "Origin")
+			}
+			c.Header("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				c.Header("Access-Control-Allow-Headers", headers)
+			}
+		}
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestLogger journalise les requêtes au format texte (gin) ou JSON, sur la sortie configurée;
+// aux niveaux warn et error, seules les réponses 4xx/5xx ou 5xx sont journalisées
+func requestLogger(cfg *config.APIConfig) gin.HandlerFunc {
+	logging := cfg.API.Logging
+	var out io.Writer = os.Stdout
+	if logging.Output == config.LogOutputStderr {
+		out = os.Stderr
+	}
+
+	minStatus := 0
+	switch logging.Level {
+	case "warn":
+		minStatus = http.StatusBadRequest
+	case "error":
+		minStatus = http.StatusInternalServerError
+	}
+
+	loggerConfig := gin.LoggerConfig{
+		Output: out,
+		Skip: func(c *gin.Context) bool {
+			return c.Writer.Status() < minStatus
+		},
+	}
+	if logging.Format == config.LogFormatJSON {
+		loggerConfig.Formatter = jsonLogFormatter
+	}
+	return gin.LoggerWithConfig(loggerConfig)
+}
+
+// jsonLogFormatter formate une requête en une ligne JSON
+func jsonLogFormatter(p gin.LogFormatterParams) string {
+	line, _ := json.Marshal(struct {
+		Time      string  `json:"time"`
+		Status    int     `json:"status"`
+		LatencyMS float64 `json:"latencyMs"`
+		ClientIP  string  `json:"clientIp"`
+		Method    string  `json:"method"`
+		Path      string  `json:"path"`
+		Error     string  `json:"error,omitempty"`
+	}{
+		Time:      p.TimeStamp.Format(time.RFC3339),
+		Status:    p.StatusCode,
+		LatencyMS: float64(p.Latency.Microseconds()) / 1000,
+		ClientIP:  p.ClientIP,
+		Method:    p.Method,
+		Path:      p.Path,
+		Error:     p.ErrorMessage,
+	})
+	return string(line) + "\n"
+}
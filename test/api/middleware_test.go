@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jusgaga/wordmon-go/internal/config"
+)
+
+// newTestServer crée un serveur sur des stores en mémoire; seules les erreurs 5xx sont journalisées
+func newTestServer(cfg *config.APIConfig) *Server {
+	if cfg == nil {
+		cfg = config.DefaultAPIConfig()
+	}
+	cfg.API.Logging.Level = "error"
+	return NewServer(NewSimpleStore(), NewSimpleStore(), cfg)
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	cfg := config.DefaultAPIConfig()
+	cfg.API.CORS.Enabled = true
+	cfg.API.CORS.AllowedOrigins = []string{"http://front.example"}
+	cfg.API.CORS.AllowedMethods = []string{"GET", "POST"}
+	cfg.API.CORS.AllowedHeaders = []string{"Content-Type", "Authorization"}
+	s := newTestServer(cfg)
+
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{"origine autorisée", http.MethodGet, "http://front.example", http.StatusOK, "http://front.example"},
+		{"origine refusée", http.MethodGet, "http://evil.example", http.StatusOK, ""},
+		{"sans origine", http.MethodGet, "", http.StatusOK, ""},
+		{"requête préliminaire", http.MethodOptions, "http://front.example", http.StatusNoContent, "http://front.example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/status", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, attendu %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, attendu %q", got, tt.wantOrigin)
+			}
+			if tt.wantOrigin != "" {
+				if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+					t.Errorf("Access-Control-Allow-Methods = %q", got)
+				}
+				if got := w.Header().Get("Vary"); got != "Origin" {
+					t.Errorf("Vary = %q, attendu Origin", got)
+				}
+			}
+		})
+	}
+
+	cfg.API.CORS.AllowedOrigins = []string{"*"}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	req.Header.Set("Origin", "http://any.example")
+	newTestServer(cfg).router.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("origine joker: Access-Control-Allow-Origin = %q, attendu *", got)
+	}
+}
+
+func TestRequestLogger_JSONWarn(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	cfg := config.DefaultAPIConfig()
+	cfg.API.Logging.Level = "warn"
+	cfg.API.Logging.Format = config.LogFormatJSON
+	logger := requestLogger(cfg)
+	os.Stdout = stdout
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(logger)
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/ko", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	for _, path := range []string{"/ok", "/ko"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if _, err := out.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	sc := bufio.NewScanner(out)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) != 1 {
+		t.Fatalf("niveau warn: 1 ligne attendue (404 seulement), got %q", lines)
+	}
+	var entry struct {
+		Status int    `json:"status"`
+		Method string `json:"method"`
+		Path   string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("ligne JSON invalide %q: %v", lines[0], err)
+	}
+	if entry.Status != http.StatusNotFound || entry.Method != http.MethodGet || entry.Path != "/ko" {
+		t.Errorf("entrée = %+v, attendu GET /ko 404", entry)
+	}
+}
+
+func TestServer_Timeouts(t *testing.T) {
+	cfg := config.DefaultAPIConfig()
+	cfg.API.Server.ReadTimeout = "100ms"
+	cfg.API.Server.WriteTimeout = "2s"
+	hs := newTestServer(cfg).newHTTPServer("")
+	if hs.ReadTimeout != 100*time.Millisecond || hs.WriteTimeout != 2*time.Second {
+		t.Fatalf("délais = %v/%v, attendu 100ms/2s", hs.ReadTimeout, hs.WriteTimeout)
+	}
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = hs
+	ts.Start()
+	defer ts.Close()
+
+	// Requête jamais terminée: le serveur ferme la connexion après readTimeout
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("GET /api/status HTTP/1.1\r\nHost: wordmon\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	start := time.Now()
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		t.Fatalf("connexion toujours ouverte après %v", time.Since(start))
+	}
+	if strings.Contains(string(buf[:n]), "200 OK") {
+		t.Errorf("requête incomplète servie: %q", buf[:n])
+	}
+}
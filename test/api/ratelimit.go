@@ -0,0 +1,123 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter limite le débit par clé (adresse IP ou joueur) avec un seau à jetons:
+// chaque clé dispose de perMinute requêtes, rechargées en continu sur une minute
+type RateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket est le seau à jetons d'une clé
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter crée un limiteur de perMinute requêtes par minute et par clé
+func NewRateLimiter(perMinute int) *RateLimiter {
+	return &RateLimiter{
+		perMinute: perMinute,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow consomme un jeton pour la clé; si le seau est vide, retourne false
+// et le délai avant qu'un jeton soit disponible
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	return l.AllowAll(key)
+}
+
+// AllowAll consomme un jeton dans le seau de chaque clé, seulement si tous en ont un;
+// sinon aucun jeton n'est consommé et le délai retourné est celui du seau le plus long à se remplir
+func (l *RateLimiter) AllowAll(keys ...string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	capacity := float64(l.perMinute)
+	buckets := make([]*bucket, len(keys))
+	var wait time.Duration
+	for i, key := range keys {
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &bucket{tokens: capacity, last: now}
+			l.buckets[key] = b
+		}
+		b.tokens += now.Sub(b.last).Minutes() * capacity
+		if b.tokens > capacity {
+			b.tokens = capacity
+		}
+		b.last = now
+		if b.tokens < 1 {
+			wait = max(wait, time.Duration((1-b.tokens)/capacity*float64(time.Minute)))
+		}
+		buckets[i] = b
+	}
+
+	if wait > 0 {
+		return false, wait
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// sweep oublie, au plus une fois par minute, les seaux pleins depuis au moins une minute
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= time.Minute {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// rateKeys retourne les clés de débit d'une requête: son adresse IP et, si la
+// requête est authentifiée (RequireAuth doit précéder), le joueur du jeton
+func rateKeys(c *gin.Context) []string {
+	keys := []string{"ip:" + c.ClientIP()}
+	if id, ok := c.Get(authPlayerKey); ok {
+		keys = append(keys, "player:"+id.(string))
+	}
+	return keys
+}
+
+// Middleware refuse avec 429 les requêtes dont l'adresse IP ou le joueur authentifié a épuisé
+// son débit; un jeton n'est consommé dans aucun des deux seaux si l'un d'eux est vide
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, wait := l.AllowAll(rateKeys(c)...); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "rate_limited",
+				Message: "Trop de requêtes, réessayez plus tard",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// passThrough laisse passer toutes les requêtes (limitation désactivée)
+func passThrough(c *gin.Context) {
+	c.Next()
+}
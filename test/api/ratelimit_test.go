@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jusgaga/wordmon-go/internal/config"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	l := NewRateLimiter(2)
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("ip:a"); !ok {
+			t.Fatalf("requête %d refusée, 2 autorisées par minute", i+1)
+		}
+	}
+	ok, wait := l.Allow("ip:a")
+	if ok {
+		t.Fatal("3e requête autorisée, 2 par minute")
+	}
+	if wait <= 0 || wait > 30*time.Second {
+		t.Errorf("attente = %v, attendu ]0, 30s] (un jeton toutes les 30s)", wait)
+	}
+	if ok, _ := l.Allow("ip:b"); !ok {
+		t.Error("une autre clé dispose de son propre seau")
+	}
+}
+
+// rateLimitedServer crée un serveur limité à reads requêtes et attempts tentatives par minute
+func rateLimitedServer(reads, attempts int) *Server {
+	cfg := config.DefaultAPIConfig()
+	cfg.API.RateLimit.Enabled = true
+	cfg.API.RateLimit.RequestsPerMinute = reads
+	cfg.API.RateLimit.AttemptsPerMinute = attempts
+	return newTestServer(cfg)
+}
+
+func serveFrom(s *Server, remoteAddr, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_SeparateBudgets(t *testing.T) {
+	s := rateLimitedServer(2, 1)
+	const ip = "192.0.2.1:1234"
+
+	for i := 0; i < 2; i++ {
+		if w := serveFrom(s, ip, http.MethodGet, "/api/status", ""); w.Code != http.StatusOK {
+			t.Fatalf("lecture %d: status %d", i+1, w.Code)
+		}
+	}
+	w := serveFrom(s, ip, http.MethodGet, "/api/status", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("3e lecture: status %d, attendu 429", w.Code)
+	}
+	if after, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || after < 1 || after > 30 {
+		t.Errorf("Retry-After = %q, attendu 1 à 30 secondes", w.Header().Get("Retry-After"))
+	}
+
+	// Les tentatives ont leur propre budget, indépendant des lectures épuisées
+	attempt := `{"playerId":"p1","attempt":"chat"}`
+	if w := serveFrom(s, ip, http.MethodPost, "/api/encounter/attempt", attempt); w.Code == http.StatusTooManyRequests {
+		t.Fatal("1re tentative limitée par le budget des lectures")
+	}
+	if w := serveFrom(s, ip, http.MethodPost, "/api/encounter/attempt", attempt); w.Code != http.StatusTooManyRequests {
+		t.Errorf("2e tentative: status %d, attendu 429", w.Code)
+	}
+}
+
+func TestRateLimitMiddleware_UntrustedForwardedFor(t *testing.T) {
+	s := rateLimitedServer(1, 1)
+	req := func(forwarded string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("X-Forwarded-For", forwarded)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, r)
+		return w.Code
+	}
+	req("203.0.113.1")
+	if code := req("203.0.113.2"); code != http.StatusTooManyRequests {
+		t.Errorf("X-Forwarded-For d'un proxy non déclaré pris en compte: status %d, attendu 429", code)
+	}
+}
+
+func TestRateLimiter_AllowAll(t *testing.T) {
+	l := NewRateLimiter(2)
+	l.Allow("ip:a")
+	l.Allow("ip:a")
+
+	if ok, wait := l.AllowAll("player:p1", "ip:a"); ok || wait <= 0 {
+		t.Fatalf("AllowAll = %v, %v ; attendu refus, le seau ip:a est vide", ok, wait)
+	}
+	// Le refus n'a rien consommé dans le seau du joueur
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("player:p1"); !ok {
+			t.Errorf("requête %d du joueur refusée: jeton consommé par le refus", i+1)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_UnauthenticatedPlayerID(t *testing.T) {
+	s := rateLimitedServer(10, 1)
+	attempt := `{"playerId":"p1","attempt":"chat"}`
+
+	if w := serveFrom(s, "192.0.2.1:1234", http.MethodPost, "/api/encounter/attempt", attempt); w.Code == http.StatusTooManyRequests {
+		t.Fatal("1re tentative limitée")
+	}
+	// Sans authentification, le playerId du corps n'est pas une clé: il ne vide pas le budget de p1
+	if w := serveFrom(s, "198.51.100.7:1234", http.MethodPost, "/api/encounter/attempt", attempt); w.Code == http.StatusTooManyRequests {
+		t.Error("même playerId non authentifié, autre adresse: ne devrait pas être limité")
+	}
+}
+
+func TestRateLimitMiddleware_AuthenticatedPlayer(t *testing.T) {
+	cfg := config.DefaultAPIConfig()
+	cfg.API.Security.EnableAuth = true
+	cfg.API.Security.JWTSecret = testJWTSecret
+	cfg.API.RateLimit.Enabled = true
+	cfg.API.RateLimit.RequestsPerMinute = 1
+	s := newTestServer(cfg)
+	token, _ := s.handlers.tokens.Issue("p1")
+
+	get := func(remoteAddr, path, token string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := get("192.0.2.1:1234", "/api/players/p1", token); code == http.StatusTooManyRequests {
+		t.Fatal("1re lecture limitée")
+	}
+	// Le budget du joueur authentifié le suit d'une adresse à l'autre
+	if code := get("198.51.100.7:1234", "/api/players/p1", token); code != http.StatusTooManyRequests {
+		t.Fatalf("même joueur, autre adresse: status %d, attendu 429", code)
+	}
+	// La requête refusée n'a pas consommé le jeton de la nouvelle adresse
+	if code := get("198.51.100.7:1234", "/api/status", ""); code == http.StatusTooManyRequests {
+		t.Error("adresse limitée par une requête refusée pour le joueur")
+	}
+}
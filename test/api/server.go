@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jusgaga/wordmon-go/internal/config"
 	"github.com/jusgaga/wordmon-go/internal/core"
 )
 
@@ -15,23 +16,58 @@ type Server struct {
 	router   *gin.Engine
 	handlers *Handlers
 	server   *http.Server
+	config   *config.APIConfig
+
+	// Limitation de débit: lectures et autres requêtes, tentatives de capture
+	readLimit    gin.HandlerFunc
+	attemptLimit gin.HandlerFunc
 }
 
-// NewServer crée une nouvelle instance du serveur
-func NewServer(playerStore PlayerStore, spawnStore SpawnStore) *Server {
+// NewServer crée une nouvelle instance du serveur selon la configuration de l'API
+// (journalisation, CORS, limitation de débit, délais); nil équivaut à config.DefaultAPIConfig()
+func NewServer(playerStore PlayerStore, spawnStore SpawnStore, cfg *config.APIConfig) *Server {
+	if cfg == nil {
+		cfg = config.DefaultAPIConfig()
+	}
+
 	// Configurer Gin
-	gin.SetMode(gin.ReleaseMode)
+	if cfg.API.Logging.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
 	router := gin.New()
+	// X-Forwarded-For n'est cru que des proxys déclarés (nil: jamais)
+	if err := router.SetTrustedProxies(cfg.API.Server.TrustedProxies); err != nil {
+		log.Printf("[API] trustedProxies invalides, aucun proxy de confiance: %v", err)
+		_ = router.SetTrustedProxies(nil)
+	}
 
 	// Middleware
-	router.Use(gin.Logger())
+	router.Use(requestLogger(cfg))
 	router.Use(gin.Recovery())
+	if cfg.API.CORS.Enabled {
+		router.Use(corsMiddleware(cfg))
+	}
 
 	handlers := NewHandlers(playerStore, spawnStore)
+	handlers.upgrader = newUpgrader(cfg)
+	if cfg.API.Security.EnableAuth {
+		handlers.SetTokenSigner(NewTokenSigner(cfg.API.Security.JWTSecret, cfg.SessionTimeout()))
+	}
 
 	server := &Server{
-		router:   router,
-		handlers: handlers,
+		router:       router,
+		handlers:     handlers,
+		config:       cfg,
+		readLimit:    passThrough,
+		attemptLimit: passThrough,
+	}
+	if cfg.API.RateLimit.Enabled {
+		attempts := NewRateLimiter(cfg.AttemptsPerMinute())
+		server.readLimit = NewRateLimiter(cfg.API.RateLimit.RequestsPerMinute).Middleware()
+		server.attemptLimit = attempts.Middleware()
+		handlers.SetAttemptLimiter(attempts)
 	}
 
 	server.setupRoutes()
@@ -40,48 +76,71 @@ func NewServer(playerStore PlayerStore, spawnStore SpawnStore) *Server {
 
 // setupRoutes configure toutes les routes de l'API
 func (s *Server) setupRoutes() {
+	// Profil et rencontres: jeton requis quand l'authentification est activée.
+	// Limitation de débit après auth, pour la compter par joueur authentifié
+	auth := s.handlers.RequireAuth
+	reads, attempts := s.readLimit, s.attemptLimit
+
 	// Groupe API (optionnel pour la versioning)
 	api := s.router.Group("/api")
 	{
 		// Status
-		api.GET("/status", s.handlers.GetStatus)
+		api.GET("/status", reads, s.handlers.GetStatus)
 
 		// Players
-		api.POST("/players", s.handlers.CreatePlayer)
-		api.GET("/players/:id", s.handlers.GetPlayer)
+		api.POST("/players", reads, s.handlers.CreatePlayer)
+		api.GET("/players/:id", auth, reads, s.handlers.GetPlayer)
+		api.GET("/players/:id/events", auth, reads, s.handlers.GetPlayerEvents)
 
 		// Spawn
-		api.GET("/spawn/current", s.handlers.GetCurrentSpawn)
+		api.GET("/spawn/current", reads, s.handlers.GetCurrentSpawn)
 
 		// Encounter
-		api.POST("/encounter/attempt", s.handlers.AttemptCapture)
+		api.POST("/encounter/attempt", auth, attempts, s.handlers.AttemptCapture)
+		api.POST("/encounters", auth, reads, s.handlers.StartEncounter)
+		api.GET("/encounters/:id", auth, reads, s.handlers.GetEncounter)
+		api.POST("/encounters/:id/attempts", auth, attempts, s.handlers.SubmitEncounterAttempt)
 
 		// Leaderboard
-		api.GET("/leaderboard", s.handlers.GetLeaderboard)
+		api.GET("/leaderboard", reads, s.handlers.GetLeaderboard)
+
+		// Flux d'événements (SSE) et protocole temps réel (WebSocket)
+		api.GET("/events", reads, s.handlers.StreamEvents)
+		api.GET("/ws", reads, s.handlers.ServeWebSocket)
 	}
 
 	// Routes racine pour la compatibilité
-	s.router.GET("/status", s.handlers.GetStatus)
-	s.router.POST("/players", s.handlers.CreatePlayer)
-	s.router.GET("/players/:id", s.handlers.GetPlayer)
-	s.router.GET("/spawn/current", s.handlers.GetCurrentSpawn)
-	s.router.POST("/encounter/attempt", s.handlers.AttemptCapture)
-	s.router.GET("/leaderboard", s.handlers.GetLeaderboard)
+	s.router.GET("/status", reads, s.handlers.GetStatus)
+	s.router.POST("/players", reads, s.handlers.CreatePlayer)
+	s.router.GET("/players/:id", auth, reads, s.handlers.GetPlayer)
+	s.router.GET("/spawn/current", reads, s.handlers.GetCurrentSpawn)
+	s.router.POST("/encounter/attempt", auth, attempts, s.handlers.AttemptCapture)
+	s.router.GET("/leaderboard", reads, s.handlers.GetLeaderboard)
 }
 
-// Start démarre le serveur sur le port spécifié
+// Start démarre le serveur sur l'adresse spécifiée, avec les délais de la configuration
 func (s *Server) Start(addr string) error {
-	s.server = &http.Server{
-		Addr:    addr,
-		Handler: s.router,
-	}
+	s.server = s.newHTTPServer(addr)
 
 	log.Printf("[API] Serveur démarré sur %s", addr)
 	return s.server.ListenAndServe()
 }
 
-// Stop arrête proprement le serveur
+// newHTTPServer construit le serveur HTTP du routeur avec les délais de la configuration
+func (s *Server) newHTTPServer(addr string) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      s.router,
+		ReadTimeout:  s.config.ReadTimeout(),
+		WriteTimeout: s.config.WriteTimeout(),
+	}
+}
+
+// Stop arrête proprement le serveur, après avoir fermé les flux d'événements
+// et les connexions WebSocket (non suivies par http.Server.Shutdown)
 func (s *Server) Stop() error {
+	s.handlers.CloseSockets()
+	s.handlers.Feed().Close()
 	if s.server != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -95,6 +154,21 @@ func (s *Server) SetSpawner(spawner chan core.SpawnEvent) {
 	s.handlers.SetSpawner(spawner)
 }
 
+// PublishSpawn diffuse un nouveau spawn aux abonnés du flux d'événements
+func (s *Server) PublishSpawn(spawn core.SpawnEvent) {
+	s.handlers.PublishSpawn(spawn)
+}
+
+// SetRules configure les règles de jeu pour les handlers
+func (s *Server) SetRules(rules *core.Rules) {
+	s.handlers.SetRules(rules)
+}
+
+// SetCaptureService configure le service d'enregistrement des captures pour les handlers
+func (s *Server) SetCaptureService(captures CaptureService) {
+	s.handlers.SetCaptureService(captures)
+}
+
 // GetHandlers retourne les handlers pour l'intégration
 func (s *Server) GetHandlers() *Handlers {
 	return s.handlers
@@ -2,13 +2,14 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jusgaga/wordmon-go/internal/core"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // SQLStore implémente le stockage SQL pour PostgreSQL
@@ -32,6 +33,11 @@ func NewSQLStore(databaseURL string) (*SQLStore, error) {
 	return &SQLStore{db: db}, nil
 }
 
+// DB retourne la connexion sous-jacente (migrations, journal d'événements)
+func (s *SQLStore) DB() *sql.DB {
+	return s.db
+}
+
 // Close ferme la connexion à la base de données
 func (s *SQLStore) Close() error {
 	return s.db.Close()
@@ -61,16 +67,20 @@ func (s *SQLStore) CreatePlayer(name string) (*PlayerResponse, error) {
 
 // GetPlayer récupère un joueur par son ID
 func (s *SQLStore) GetPlayer(id string) (*PlayerResponse, error) {
-	query := `SELECT id, name, xp, level FROM players WHERE id = $1`
+	query := `SELECT id, name, xp, level, version, rewards FROM players WHERE id = $1`
 
 	var player PlayerResponse
-	err := s.db.QueryRow(query, id).Scan(&player.ID, &player.Name, &player.XP, &player.Level)
+	var rewards []byte
+	err := s.db.QueryRow(query, id).Scan(&player.ID, &player.Name, &player.XP, &player.Level, &player.Version, &rewards)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &PlayerNotFoundError{ID: id}
 		}
 		return nil, fmt.Errorf("erreur récupération joueur: %w", err)
 	}
+	if err := json.Unmarshal(rewards, &player.PlayerRewards); err != nil {
+		return nil, fmt.Errorf("erreur lecture récompenses: %w", err)
+	}
 
 	// Récupérer l'inventaire
 	inventory, err := s.ListByPlayer(id)
@@ -111,34 +121,20 @@ func (s *SQLStore) GetAllPlayers() []*PlayerResponse {
 	return players
 }
 
-// UpdatePlayer met à jour un joueur
+// UpdatePlayer met à jour un joueur (XP, niveau et récompenses dans la même requête)
+// si sa version n'a pas changé depuis sa lecture (ConflictError sinon); en cas de
+// succès, player.Version est incrémentée
 func (s *SQLStore) UpdatePlayer(player *PlayerResponse) error {
-	query := `UPDATE players SET name = $1, xp = $2, level = $3 WHERE id = $4`
-
-	result, err := s.db.Exec(query, player.Name, player.XP, player.Level, player.ID)
-	if err != nil {
-		return fmt.Errorf("erreur mise à jour joueur: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	rewards, err := json.Marshal(player.PlayerRewards)
 	if err != nil {
-		return fmt.Errorf("erreur vérification rows affected: %w", err)
+		return fmt.Errorf("erreur encodage récompenses: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return &PlayerNotFoundError{ID: player.ID}
-	}
-
-	return nil
-}
-
-// UpdateXP met à jour l'XP et le niveau d'un joueur
-func (s *SQLStore) UpdateXP(id string, newXP int, newLevel int) error {
-	query := `UPDATE players SET xp = $1, level = $2 WHERE id = $3`
+	query := `UPDATE players SET name = $1, xp = $2, level = $3, rewards = $4, version = version + 1 WHERE id = $5 AND version = $6`
 
-	result, err := s.db.Exec(query, newXP, newLevel, id)
+	result, err := s.db.Exec(query, player.Name, player.XP, player.Level, string(rewards), player.ID, player.Version)
 	if err != nil {
-		return fmt.Errorf("erreur mise à jour XP: %w", err)
+		return fmt.Errorf("erreur mise à jour joueur: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -147,9 +143,18 @@ func (s *SQLStore) UpdateXP(id string, newXP int, newLevel int) error {
 	}
 
 	if rowsAffected == 0 {
-		return &PlayerNotFoundError{ID: id}
+		// Joueur absent ou modifié entre-temps
+		var exists bool
+		if err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM players WHERE id = $1)`, player.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("erreur vérification joueur: %w", err)
+		}
+		if !exists {
+			return &PlayerNotFoundError{ID: player.ID}
+		}
+		return &ConflictError{ID: player.ID, Version: player.Version}
 	}
 
+	player.Version++
 	return nil
 }
 
@@ -172,45 +177,136 @@ func (s *SQLStore) GetStartTime() time.Time {
 	return time.Now()
 }
 
-// AddSpawn ajoute un spawn à l'historique (non persisté en SQL pour l'instant)
+// AddSpawn enregistre un spawn dans la table spawns
 func (s *SQLStore) AddSpawn(spawn interface{}) error {
-	// Pour l'instant, on ne persiste pas les spawns
+	spawnEvent, ok := spawn.(core.SpawnEvent)
+	if !ok {
+		return &InvalidSpawnError{}
+	}
+	if spawnEvent.ID == "" {
+		spawnEvent.ID = uuid.New().String()
+	}
+
+	var expiresAt sql.NullTime
+	if !spawnEvent.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: spawnEvent.ExpiresAt, Valid: true}
+	}
+
+	query := `INSERT INTO spawns (id, round, word_id, word, rarity, points, spawned_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := s.db.Exec(query, spawnEvent.ID, spawnEvent.Round, spawnEvent.Word.ID, spawnEvent.Word.Text,
+		spawnEvent.Word.Rarity, spawnEvent.Word.Points, time.Now(), expiresAt)
+	if err != nil {
+		return fmt.Errorf("erreur ajout spawn: %w", err)
+	}
+
 	return nil
 }
 
-// GetCurrentSpawn récupère le spawn actuel (généré dynamiquement)
+// GetCurrentSpawn récupère le dernier spawn écrit par le spawner, s'il n'a pas expiré
+// (un spawn plus ancien encore valide a été remplacé et n'est plus courant)
 func (s *SQLStore) GetCurrentSpawn() interface{} {
-	// Générer un spawn aléatoire depuis la base
-	word, err := s.RandomByRarity("Common")
+	query := `
+		SELECT id, round, word_id, word, rarity, points, expires_at
+		FROM spawns
+		ORDER BY spawned_at DESC
+		LIMIT 1
+	`
+
+	var spawnEvent core.SpawnEvent
+	var expiresAt sql.NullTime
+	err := s.db.QueryRow(query).Scan(&spawnEvent.ID, &spawnEvent.Round, &spawnEvent.Word.ID,
+		&spawnEvent.Word.Text, &spawnEvent.Word.Rarity, &spawnEvent.Word.Points, &expiresAt)
 	if err != nil {
-		log.Printf("erreur génération spawn: %v", err)
+		if err != sql.ErrNoRows {
+			log.Printf("erreur récupération spawn: %v", err)
+		}
 		return nil
 	}
+	if expiresAt.Valid {
+		if !expiresAt.Time.After(time.Now()) {
+			return nil
+		}
+		spawnEvent.ExpiresAt = expiresAt.Time
+	}
+
+	return spawnEvent
+}
+
+// ClaimSpawn réserve le spawn au joueur s'il n'a pas encore de gagnant et retourne le gagnant
+// (la mise à jour conditionnelle garantit qu'un seul joueur l'emporte)
+func (s *SQLStore) ClaimSpawn(spawnID, playerID string) (string, error) {
+	query := `UPDATE spawns SET claimed_by = $1 WHERE id = $2 AND claimed_by IS NULL`
+	if _, err := s.db.Exec(query, playerID, spawnID); err != nil {
+		return "", fmt.Errorf("erreur réservation spawn: %w", err)
+	}
+
+	winner, ok := s.SpawnWinner(spawnID)
+	if !ok {
+		return "", fmt.Errorf("erreur réservation spawn: spawn %s introuvable", spawnID)
+	}
+	return winner, nil
+}
+
+// ReleaseSpawn annule la réservation du spawn si elle appartient au joueur
+func (s *SQLStore) ReleaseSpawn(spawnID, playerID string) error {
+	query := `UPDATE spawns SET claimed_by = NULL WHERE id = $1 AND claimed_by = $2`
+	if _, err := s.db.Exec(query, spawnID, playerID); err != nil {
+		return fmt.Errorf("erreur libération spawn: %w", err)
+	}
+	return nil
+}
 
-	return core.SpawnEvent{
-		Round: 1,
-		Word:  *word,
+// SpawnWinner retourne le joueur ayant remporté le spawn
+func (s *SQLStore) SpawnWinner(spawnID string) (string, bool) {
+	query := `SELECT claimed_by FROM spawns WHERE id = $1 AND claimed_by IS NOT NULL`
+
+	var winner string
+	if err := s.db.QueryRow(query, spawnID).Scan(&winner); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("erreur récupération gagnant du spawn: %v", err)
+		}
+		return "", false
 	}
+	return winner, true
 }
 
-// Seed insère les mots dans la base de données
-func (s *SQLStore) Seed(words []core.Word) error {
-	// Vider la table words d'abord
-	_, err := s.db.Exec("DELETE FROM words")
+// Seed insère ou met à jour les mots dans la base de données; les captures existantes sont conservées
+func (s *SQLStore) Seed(words []core.Word) (err error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return fmt.Errorf("erreur vidage table words: %w", err)
+		return fmt.Errorf("erreur début transaction seed: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("erreur annulation seed: %v", rbErr)
+			}
+		}
+	}()
 
-	// Insérer les nouveaux mots
-	query := `INSERT INTO words (id, text, rarity, points) VALUES ($1, $2, $3, $4)`
+	// Insérer ou mettre à jour les mots, sans toucher aux captures qui les référencent
+	query := `INSERT INTO words (id, text, rarity, points) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET text = EXCLUDED.text, rarity = EXCLUDED.rarity, points = EXCLUDED.points`
 
+	ids := make([]string, 0, len(words))
 	for _, word := range words {
-		_, err := s.db.Exec(query, word.ID, word.Text, word.Rarity, word.Points)
-		if err != nil {
+		if _, err = tx.Exec(query, word.ID, word.Text, word.Rarity, word.Points); err != nil {
 			return fmt.Errorf("erreur insertion mot %s: %w", word.Text, err)
 		}
+		ids = append(ids, word.ID)
 	}
 
+	// Retirer les mots sortis de la configuration, sauf ceux déjà capturés
+	// (la suppression effacerait leurs captures en cascade)
+	query = `DELETE FROM words WHERE NOT (id = ANY($1))
+		AND NOT EXISTS (SELECT 1 FROM captures WHERE captures.word_id = words.id)`
+	if _, err = tx.Exec(query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("erreur nettoyage table words: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("erreur validation seed: %w", err)
+	}
 	log.Printf("[seed] %d words loaded into DB", len(words))
 	return nil
 }
@@ -263,6 +359,16 @@ func (s *SQLStore) Add(playerId, wordId string) error {
 
 // ListByPlayer récupère tous les mots capturés par un joueur
 func (s *SQLStore) ListByPlayer(playerId string) ([]core.Word, error) {
+	return listCaptures(s.db, playerId)
+}
+
+// queryer est implémenté par *sql.DB et *sql.Tx
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// listCaptures récupère les mots capturés par un joueur, dans ou hors transaction
+func listCaptures(q queryer, playerId string) ([]core.Word, error) {
 	query := `
 		SELECT w.id, w.text, w.rarity, w.points 
 		FROM words w 
@@ -271,7 +377,7 @@ func (s *SQLStore) ListByPlayer(playerId string) ([]core.Word, error) {
 		ORDER BY c.captured_at DESC
 	`
 
-	rows, err := s.db.Query(query, playerId)
+	rows, err := q.Query(query, playerId)
 	if err != nil {
 		return nil, fmt.Errorf("erreur récupération captures: %w", err)
 	}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// nullArg vérifie qu'un argument SQL est NULL
+type nullArg struct{}
+
+func (nullArg) Match(v driver.Value) bool { return v == nil }
+
+// nonEmptyArg vérifie qu'un argument SQL est une chaîne non vide
+type nonEmptyArg struct{}
+
+func (nonEmptyArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && s != ""
+}
+
+const insertSpawnQuery = `INSERT INTO spawns (id, round, word_id, word, rarity, points, spawned_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+var spawnColumns = []string{"id", "round", "word_id", "word", "rarity", "points", "expires_at"}
+
+func TestSQLStore_AddSpawn(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute).UTC()
+	word := core.Word{ID: "w1", Text: "chien", Rarity: core.Common, Points: 10}
+
+	tests := []struct {
+		name      string
+		spawn     core.SpawnEvent
+		id        sqlmock.Argument
+		expiresAt sqlmock.Argument
+	}{
+		{"spawn complet", core.SpawnEvent{ID: "s1", Round: 3, Word: word, ExpiresAt: expiresAt}, sqlmock.AnyArg(), sqlmock.AnyArg()},
+		// sans identifiant, le store en génère un; sans expiration, la colonne reste NULL
+		{"spawn sans id ni expiration", core.SpawnEvent{Round: 1, Word: word}, nonEmptyArg{}, nullArg{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockSQLStore(t)
+			mock.ExpectExec(regexp.QuoteMeta(insertSpawnQuery)).
+				WithArgs(tt.id, tt.spawn.Round, "w1", "chien", core.Common, 10, sqlmock.AnyArg(), tt.expiresAt).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := store.AddSpawn(tt.spawn); err != nil {
+				t.Fatalf("AddSpawn: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	store, mock := newMockSQLStore(t)
+	var invalid *InvalidSpawnError
+	if err := store.AddSpawn("pas un spawn"); !errors.As(err, &invalid) {
+		t.Errorf("type invalide: InvalidSpawnError attendue, got %v", err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta(insertSpawnQuery)).WillReturnError(errors.New("connexion perdue"))
+	if err := store.AddSpawn(core.SpawnEvent{ID: "s1", Word: word}); err == nil {
+		t.Error("AddSpawn devrait remonter l'erreur d'insertion")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLStore_GetCurrentSpawn(t *testing.T) {
+	future := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		want interface{}
+	}{
+		{
+			"dernier spawn encore valide",
+			sqlmock.NewRows(spawnColumns).AddRow("s2", 2, "w2", "dragon", "Rare", 50, future),
+			core.SpawnEvent{ID: "s2", Round: 2, Word: core.Word{ID: "w2", Text: "dragon", Rarity: core.Rare, Points: 50}, ExpiresAt: future},
+		},
+		{
+			"spawn sans expiration",
+			sqlmock.NewRows(spawnColumns).AddRow("s1", 1, "w1", "chien", "Common", 10, nil),
+			core.SpawnEvent{ID: "s1", Round: 1, Word: core.Word{ID: "w1", Text: "chien", Rarity: core.Common, Points: 10}},
+		},
+		{
+			// le dernier spawn a expiré: aucun spawn plus ancien ne redevient courant
+			"dernier spawn expiré",
+			sqlmock.NewRows(spawnColumns).AddRow("s2", 2, "w2", "dragon", "Rare", 50, time.Now().Add(-time.Second)),
+			nil,
+		},
+		{"aucun spawn", sqlmock.NewRows(spawnColumns), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockSQLStore(t)
+			mock.ExpectQuery(`FROM spawns\s+ORDER BY spawned_at DESC\s+LIMIT 1`).WillReturnRows(tt.rows)
+
+			got := store.GetCurrentSpawn()
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("GetCurrentSpawn() = %+v, attendu nil", got)
+				}
+			} else if spawn, ok := got.(core.SpawnEvent); !ok || spawn.ID != tt.want.(core.SpawnEvent).ID ||
+				spawn.Word != tt.want.(core.SpawnEvent).Word || !spawn.ExpiresAt.Equal(tt.want.(core.SpawnEvent).ExpiresAt) {
+				t.Errorf("GetCurrentSpawn() = %+v, attendu %+v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSQLStore_ClaimSpawn(t *testing.T) {
+	const claimQuery = `UPDATE spawns SET claimed_by = $1 WHERE id = $2 AND claimed_by IS NULL`
+	const winnerQuery = `SELECT claimed_by FROM spawns WHERE id = $1 AND claimed_by IS NOT NULL`
+
+	tests := []struct {
+		name     string
+		affected int64
+		winner   string
+	}{
+		{"premier joueur", 1, "p1"},
+		// la réservation conditionnelle n'a rien modifié: le gagnant reste l'autre joueur
+		{"spawn déjà réservé", 0, "p2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockSQLStore(t)
+			mock.ExpectExec(regexp.QuoteMeta(claimQuery)).WithArgs("p1", "s1").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectQuery(regexp.QuoteMeta(winnerQuery)).WithArgs("s1").
+				WillReturnRows(sqlmock.NewRows([]string{"claimed_by"}).AddRow(tt.winner))
+
+			winner, err := store.ClaimSpawn("s1", "p1")
+			if err != nil || winner != tt.winner {
+				t.Errorf("ClaimSpawn() = %q, %v, attendu %q", winner, err, tt.winner)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	store, mock := newMockSQLStore(t)
+	mock.ExpectExec(regexp.QuoteMeta(claimQuery)).WithArgs("p1", "absent").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(winnerQuery)).WithArgs("absent").WillReturnRows(sqlmock.NewRows([]string{"claimed_by"}))
+	if _, err := store.ClaimSpawn("absent", "p1"); err == nil {
+		t.Error("ClaimSpawn devrait échouer pour un spawn inconnu")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLStore_ReleaseSpawn(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE spawns SET claimed_by = NULL WHERE id = $1 AND claimed_by = $2`)).
+		WithArgs("s1", "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT claimed_by FROM spawns`).WithArgs("s1").
+		WillReturnRows(sqlmock.NewRows([]string{"claimed_by"}))
+
+	if err := store.ReleaseSpawn("s1", "p1"); err != nil {
+		t.Fatalf("ReleaseSpawn: %v", err)
+	}
+	if winner, ok := store.SpawnWinner("s1"); ok {
+		t.Errorf("SpawnWinner() = %q après libération, attendu aucun gagnant", winner)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
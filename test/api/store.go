@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ type SimpleStore struct {
 	mu            sync.RWMutex
 	players       map[string]*PlayerResponse
 	spawns        []core.SpawnEvent
+	claims        map[string]string // spawn -> joueur l'ayant remporté
 	startTime     time.Time
 	playerCounter int
 }
@@ -21,6 +23,7 @@ func NewSimpleStore() *SimpleStore {
 	return &SimpleStore{
 		players:       make(map[string]*PlayerResponse),
 		spawns:        make([]core.SpawnEvent, 0),
+		claims:        make(map[string]string),
 		startTime:     time.Now(),
 		playerCounter: 0,
 	}
@@ -50,10 +53,10 @@ func (s *SimpleStore) CreatePlayer(name string) (*PlayerResponse, error) {
 	}
 
 	s.players[playerID] = player
-	return player, nil
+	return clonePlayer(player), nil
 }
 
-// GetPlayer récupère un joueur par son ID
+// GetPlayer récupère une copie du joueur: la modifier n'a aucun effet sans UpdatePlayer
 func (s *SimpleStore) GetPlayer(id string) (*PlayerResponse, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -63,47 +66,38 @@ func (s *SimpleStore) GetPlayer(id string) (*PlayerResponse, error) {
 		return nil, &PlayerNotFoundError{ID: id}
 	}
 
-	return player, nil
+	return clonePlayer(player), nil
 }
 
-// GetAllPlayers récupère tous les joueurs
+// GetAllPlayers récupère une copie de tous les joueurs
 func (s *SimpleStore) GetAllPlayers() []*PlayerResponse {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	players := make([]*PlayerResponse, 0, len(s.players))
 	for _, player := range s.players {
-		players = append(players, player)
+		players = append(players, clonePlayer(player))
 	}
 
 	return players
 }
 
-// UpdatePlayer met à jour un joueur
+// UpdatePlayer met à jour un joueur si sa version n'a pas changé depuis sa lecture
+// (ConflictError sinon); en cas de succès, player.Version est incrémentée
 func (s *SimpleStore) UpdatePlayer(player *PlayerResponse) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.players[player.ID]; !exists {
+	current, exists := s.players[player.ID]
+	if !exists {
 		return &PlayerNotFoundError{ID: player.ID}
 	}
-
-	s.players[player.ID] = player
-	return nil
-}
-
-// UpdateXP met à jour l'XP et le niveau d'un joueur
-func (s *SimpleStore) UpdateXP(id string, newXP int, newLevel int) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	player, exists := s.players[id]
-	if !exists {
-		return &PlayerNotFoundError{ID: id}
+	if current.Version != player.Version {
+		return &ConflictError{ID: player.ID, Version: player.Version}
 	}
 
-	player.XP = newXP
-	player.Level = newLevel
+	player.Version++
+	s.players[player.ID] = clonePlayer(player)
 	return nil
 }
 
@@ -131,6 +125,38 @@ func (s *SimpleStore) GetCurrentSpawn() interface{} {
 	return s.spawns[len(s.spawns)-1]
 }
 
+// ClaimSpawn réserve le spawn au joueur s'il n'a pas encore de gagnant et retourne le gagnant
+func (s *SimpleStore) ClaimSpawn(spawnID, playerID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if winner, exists := s.claims[spawnID]; exists {
+		return winner, nil
+	}
+	s.claims[spawnID] = playerID
+	return playerID, nil
+}
+
+// ReleaseSpawn annule la réservation du spawn si elle appartient au joueur
+func (s *SimpleStore) ReleaseSpawn(spawnID, playerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.claims[spawnID] == playerID {
+		delete(s.claims, spawnID)
+	}
+	return nil
+}
+
+// SpawnWinner retourne le joueur ayant remporté le spawn
+func (s *SimpleStore) SpawnWinner(spawnID string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	winner, exists := s.claims[spawnID]
+	return winner, exists
+}
+
 // GetStartTime retourne l'heure de démarrage
 func (s *SimpleStore) GetStartTime() time.Time {
 	return s.startTime
@@ -144,6 +170,16 @@ func (s *SimpleStore) GetPlayerCount() int {
 	return len(s.players)
 }
 
+// clonePlayer retourne une copie du joueur qui ne partage ni maps ni slices avec l'original
+func clonePlayer(player *PlayerResponse) *PlayerResponse {
+	clone := *player
+	clone.Inventory = copyCounts(player.Inventory)
+	clone.Items = copyCounts(player.Items)
+	clone.UnlockedRarities = append([]string(nil), player.UnlockedRarities...)
+	clone.Titles = append([]string(nil), player.Titles...)
+	return &clone
+}
+
 // PlayerNameTakenError erreur quand le nom est déjà pris
 type PlayerNameTakenError struct {
 	Name string
@@ -162,6 +198,16 @@ func (e *PlayerNotFoundError) Error() string {
 	return "joueur non trouvé: " + e.ID
 }
 
+// ConflictError erreur quand le joueur a été modifié depuis sa lecture
+type ConflictError struct {
+	ID      string
+	Version int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflit de mise à jour du joueur %s (version %d périmée)", e.ID, e.Version)
+}
+
 // InvalidSpawnError erreur quand le spawn est invalide
 type InvalidSpawnError struct{}
 
@@ -11,12 +11,16 @@ type StatusResponse struct {
 	CurrentSpawn  *SpawnInfo `json:"currentSpawn"`
 }
 
-// SpawnInfo représente les informations d'un spawn actif
+// SpawnInfo représente les informations d'un spawn actif; le mot n'est pas
+// révélé (il est la réponse de certains défis), seule sa longueur l'est
 type SpawnInfo struct {
-	ID     string `json:"id"`
-	Text   string `json:"text"`
-	Rarity string `json:"rarity"`
-	Points int    `json:"points"`
+	ID        string     `json:"id"`
+	SpawnID   string     `json:"spawnId,omitempty"`
+	Length    int        `json:"length"`
+	Rarity    string     `json:"rarity"`
+	Points    int        `json:"points"`
+	Mode      string     `json:"mode,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // CreatePlayerRequest représente la requête pour créer un joueur
@@ -24,13 +28,38 @@ type CreatePlayerRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
+// CreatePlayerResponse représente le joueur créé et, si l'authentification est activée, son jeton
+type CreatePlayerResponse struct {
+	*PlayerResponse
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"tokenExpiresAt,omitempty"`
+}
+
 // PlayerResponse représente la réponse pour un joueur
 type PlayerResponse struct {
-	ID        string         `json:"id"`
-	Name      string         `json:"name"`
-	XP        int            `json:"xp"`
-	Level     int            `json:"level"`
-	Inventory map[string]int `json:"inventory"`
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	XP          int            `json:"xp"`
+	Level       int            `json:"level"`
+	NextLevelXP int            `json:"nextLevelXp,omitempty"`
+	Inventory   map[string]int `json:"inventory"`
+	Version     int            `json:"version"`
+	PlayerRewards
+}
+
+// PlayerRewards représente les récompenses de niveau obtenues par un joueur
+type PlayerRewards struct {
+	Items            map[string]int `json:"items,omitempty"`
+	UnlockedRarities []string       `json:"unlockedRarities,omitempty"`
+	Titles           []string       `json:"titles,omitempty"`
+}
+
+// LevelUpInfo représente un niveau franchi et sa récompense
+type LevelUpInfo struct {
+	Level            int            `json:"level"`
+	Items            map[string]int `json:"items,omitempty"`
+	UnlockedRarities []string       `json:"unlockedRarities,omitempty"`
+	Title            string         `json:"title,omitempty"`
 }
 
 // CaptureAttemptRequest représente la requête pour tenter une capture
@@ -41,12 +70,44 @@ type CaptureAttemptRequest struct {
 
 // CaptureResultResponse représente le résultat d'une tentative de capture
 type CaptureResultResponse struct {
-	Status   string `json:"status"`
-	Word     string `json:"word,omitempty"`
-	Rarity   string `json:"rarity,omitempty"`
-	XP       int    `json:"xp,omitempty"`
-	NewLevel int    `json:"newLevel,omitempty"`
-	Reason   string `json:"reason,omitempty"`
+	Status            string        `json:"status"`
+	Word              string        `json:"word,omitempty"`
+	Rarity            string        `json:"rarity,omitempty"`
+	Challenge         string        `json:"challenge,omitempty"`
+	Instructions      string        `json:"instructions,omitempty"`
+	XP                int           `json:"xp,omitempty"`
+	NewLevel          int           `json:"newLevel,omitempty"`
+	Reason            string        `json:"reason,omitempty"`
+	RemainingAttempts int           `json:"remainingAttempts"`
+	Missing           string        `json:"missing,omitempty"`
+	Extra             string        `json:"extra,omitempty"`
+	LevelUps          []LevelUpInfo `json:"levelUps,omitempty"`
+	CapturedBy        string        `json:"capturedBy,omitempty"`
+}
+
+// StartEncounterRequest représente la requête pour démarrer une rencontre
+type StartEncounterRequest struct {
+	PlayerID string `json:"playerId" binding:"required"`
+}
+
+// EncounterAttemptRequest représente une tentative dans une rencontre
+type EncounterAttemptRequest struct {
+	Attempt string `json:"attempt" binding:"required"`
+}
+
+// EncounterResponse représente l'état d'une rencontre côté serveur
+type EncounterResponse struct {
+	ID                string     `json:"id"`
+	PlayerID          string     `json:"playerId"`
+	Round             int        `json:"round"`
+	State             string     `json:"state"`
+	Word              string     `json:"word,omitempty"`
+	Rarity            string     `json:"rarity"`
+	Points            int        `json:"points"`
+	Challenge         string     `json:"challenge,omitempty"`
+	Instructions      string     `json:"instructions,omitempty"`
+	RemainingAttempts int        `json:"remainingAttempts"`
+	ExpiresAt         *time.Time `json:"expiresAt,omitempty"`
 }
 
 // LeaderboardEntry représente une entrée du leaderboard
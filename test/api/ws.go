@@ -0,0 +1,410 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/jusgaga/wordmon-go/internal/config"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// Messages envoyés par le client WebSocket
+const (
+	WSJoin           = "join"
+	WSStartEncounter = "start_encounter"
+	WSAttempt        = "attempt"
+	WSHint           = "hint"
+)
+
+// Messages poussés par le serveur WebSocket
+const (
+	WSSpawn            = "spawn"
+	WSChallenge        = "challenge"
+	WSFeedback         = "feedback"
+	WSResult           = "result"
+	WSLeaderboardDelta = "leaderboard_delta"
+	WSError            = "error"
+)
+
+const (
+	// wsWriteWait borne l'écriture d'un message
+	wsWriteWait = 10 * time.Second
+	// wsPongWait est le délai d'attente d'un pong (ou d'un message) avant de couper la connexion
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod est l'intervalle des pings, inférieur à wsPongWait
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxMessageSize borne la taille d'un message client
+	wsMaxMessageSize = 4096
+	// wsSendBuffer borne les messages en attente d'envoi; au-delà, la connexion est coupée
+	wsSendBuffer = 32
+)
+
+// WSRequest représente un message du client: join (playerId, token si
+// l'authentification est activée), start_encounter, attempt (attempt) ou hint
+type WSRequest struct {
+	Type     string `json:"type"`
+	PlayerID string `json:"playerId,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Attempt  string `json:"attempt,omitempty"`
+}
+
+// WSMessage représente un message poussé par le serveur
+type WSMessage struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+// LeaderboardDelta représente la progression d'un joueur au classement après une capture
+type LeaderboardDelta struct {
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	XP         int    `json:"xp"`
+	Level      int    `json:"level"`
+	Gained     int    `json:"gained"`
+}
+
+// wsConn est une connexion WebSocket: la lecture se fait dans le handler,
+// l'écriture dans une pompe dédiée alimentée par send et par le flux d'événements
+type wsConn struct {
+	h        *Handlers
+	conn     *websocket.Conn
+	send     chan WSMessage
+	done     chan struct{}
+	once     sync.Once
+	clientIP string
+	playerID string
+}
+
+// socketSet recense les connexions ouvertes pour les fermer à l'arrêt du serveur
+type socketSet struct {
+	mu     sync.Mutex
+	conns  map[*wsConn]struct{}
+	closed bool
+}
+
+// add recense la connexion; retourne false si le serveur s'arrête
+func (s *socketSet) add(c *wsConn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if s.conns == nil {
+		s.conns = make(map[*wsConn]struct{})
+	}
+	s.conns[c] = struct{}{}
+	return true
+}
+
+// remove oublie la connexion
+func (s *socketSet) remove(c *wsConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, c)
+}
+
+// closeAll ferme toutes les connexions avec un message de fermeture; les suivantes sont refusées
+func (s *socketSet) closeAll() {
+	s.mu.Lock()
+	s.closed = true
+	conns := make([]*wsConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.close(websocket.CloseGoingAway, "arrêt du serveur")
+	}
+}
+
+// CloseSockets ferme proprement les connexions WebSocket ouvertes (arrêt du serveur)
+func (h *Handlers) CloseSockets() {
+	h.sockets.closeAll()
+}
+
+// ServeWebSocket ouvre une connexion WebSocket au protocole JSON du jeu.
+// Le client rejoint la partie (join), puis démarre ses rencontres, soumet ses
+// tentatives et redemande le défi en cours (hint); il reçoit en retour défis,
+// retours de tentative, résultats, nouveaux spawns et progression du classement
+func (h *Handlers) ServeWebSocket(c *gin.Context) {
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade a déjà répondu au client
+		return
+	}
+
+	ws := &wsConn{
+		h:        h,
+		conn:     conn,
+		send:     make(chan WSMessage, wsSendBuffer),
+		done:     make(chan struct{}),
+		clientIP: c.ClientIP(),
+	}
+	if !h.sockets.add(ws) {
+		ws.close(websocket.CloseGoingAway, "arrêt du serveur")
+		return
+	}
+	defer h.sockets.remove(ws)
+
+	feed, _ := h.feed.Subscribe(0)
+	go ws.writePump(feed)
+	ws.readPump()
+	h.feed.Unsubscribe(feed)
+}
+
+// readPump lit et traite les messages du client jusqu'à la déconnexion
+func (ws *wsConn) readPump() {
+	defer ws.close(websocket.CloseNormalClosure, "")
+
+	ws.conn.SetReadLimit(wsMaxMessageSize)
+	_ = ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	ws.conn.SetPongHandler(func(string) error {
+		return ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var req WSRequest
+		if err := ws.conn.ReadJSON(&req); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				ws.push(WSError, ErrorResponse{Error: "invalid_request", Message: "Message JSON invalide"})
+				continue
+			}
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("[ws] connexion interrompue: %v", err)
+			}
+			return
+		}
+		_ = ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		ws.handle(req)
+	}
+}
+
+// writePump envoie les messages en attente, relaie le flux d'événements et
+// entretient la connexion par des pings; c'est le seul écrivain de la connexion
+func (ws *wsConn) writePump(feed chan FeedEvent) {
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+	defer ws.close(websocket.CloseNormalClosure, "")
+
+	for {
+		select {
+		case msg := <-ws.send:
+			if !ws.write(msg) {
+				return
+			}
+		case ev, ok := <-feed:
+			if !ok {
+				// Client trop lent pour le flux, ou serveur arrêté
+				ws.close(websocket.CloseTryAgainLater, "flux d'événements interrompu")
+				return
+			}
+			if msg, ok := feedMessage(ev); ok && !ws.write(msg) {
+				return
+			}
+		case <-ping.C:
+			_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := ws.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-ws.done:
+			return
+		}
+	}
+}
+
+// write écrit un message; retourne false si la connexion est rompue
+func (ws *wsConn) write(msg WSMessage) bool {
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return ws.conn.WriteJSON(msg) == nil
+}
+
+// push met un message en file d'envoi; une file pleine coupe la connexion
+func (ws *wsConn) push(typ string, data any) {
+	select {
+	case ws.send <- WSMessage{Type: typ, Data: data}:
+	case <-ws.done:
+	default:
+		ws.close(websocket.CloseTryAgainLater, "client trop lent")
+	}
+}
+
+// close envoie le message de fermeture puis ferme la connexion, une seule fois
+func (ws *wsConn) close(code int, reason string) {
+	ws.once.Do(func() {
+		msg := websocket.FormatCloseMessage(code, reason)
+		_ = ws.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+		close(ws.done)
+		_ = ws.conn.Close()
+	})
+}
+
+// feedMessage convertit un événement du flux en message WebSocket (spawns et classement)
+func feedMessage(ev FeedEvent) (WSMessage, bool) {
+	switch ev.Type {
+	case FeedSpawn:
+		return WSMessage{Type: WSSpawn, Data: ev.Data}, true
+	case FeedCapture:
+		capture, ok := ev.Data.(CaptureEvent)
+		if !ok {
+			return WSMessage{}, false
+		}
+		return WSMessage{Type: WSLeaderboardDelta, Data: LeaderboardDelta{
+			PlayerID:   capture.PlayerID,
+			PlayerName: capture.PlayerName,
+			XP:         capture.PlayerXP,
+			Level:      capture.PlayerLevel,
+			Gained:     capture.XP,
+		}}, true
+	default:
+		return WSMessage{}, false
+	}
+}
+
+// handle traite un message du client
+func (ws *wsConn) handle(req WSRequest) {
+	h := ws.h
+	if req.Type == WSJoin {
+		ws.join(req)
+		return
+	}
+	if ws.playerID == "" {
+		ws.push(WSError, ErrorResponse{Error: "not_joined", Message: "Envoyez d'abord un message join"})
+		return
+	}
+
+	switch req.Type {
+	case WSStartEncounter:
+		ws.reply(h.startEncounter(ws.playerID))
+
+	case WSAttempt:
+		if req.Attempt == "" {
+			ws.push(WSError, ErrorResponse{Error: "invalid_request", Message: "attempt requis"})
+			return
+		}
+		if h.attemptLimiter != nil {
+			if ok, _ := h.attemptLimiter.AllowAll(ws.rateKeys()...); !ok {
+				ws.push(WSError, ErrorResponse{Error: "rate_limited", Message: "Trop de tentatives, réessayez plus tard"})
+				return
+			}
+		}
+		info, ok := h.encounters.Get(ws.playerID)
+		if !ok {
+			ws.push(WSError, ErrorResponse{Error: "encounter_not_found", Message: "Aucune rencontre en cours (start_encounter)"})
+			return
+		}
+		ws.reply(h.attemptEncounter(info, req.Attempt))
+
+	case WSHint:
+		// Rappelle le défi en cours: consignes, tentatives restantes et échéance
+		info, ok := h.encounters.Get(ws.playerID)
+		if !ok {
+			ws.push(WSError, ErrorResponse{Error: "encounter_not_found", Message: "Aucune rencontre en cours (start_encounter)"})
+			return
+		}
+		ws.push(WSChallenge, newEncounterResponse(info))
+
+	default:
+		ws.push(WSError, ErrorResponse{Error: "invalid_request", Message: "Type de message inconnu: " + req.Type})
+	}
+}
+
+// join associe la connexion au joueur (jeton exigé si l'authentification est activée)
+// et lui envoie le spawn actuel
+func (ws *wsConn) join(req WSRequest) {
+	h := ws.h
+	if ws.playerID != "" {
+		ws.push(WSError, ErrorResponse{Error: "already_joined", Message: "Connexion déjà associée au joueur " + ws.playerID})
+		return
+	}
+
+	playerID := req.PlayerID
+	if h.tokens != nil {
+		tokenPlayer, err := h.tokens.Verify(req.Token)
+		if err != nil {
+			ws.push(WSError, ErrorResponse{Error: "unauthorized", Message: err.Error()})
+			return
+		}
+		if playerID == "" {
+			playerID = tokenPlayer
+		}
+		if playerID != tokenPlayer {
+			ws.push(WSError, ErrorResponse{Error: "forbidden", Message: "Ce jeton ne permet pas d'agir pour ce joueur"})
+			return
+		}
+	}
+
+	if _, err := h.playerStore.GetPlayer(playerID); err != nil {
+		ws.push(WSError, ErrorResponse{Error: "player_not_found", Message: "Joueur non trouvé"})
+		return
+	}
+	ws.playerID = playerID
+
+	if spawn, ok := h.spawnStore.GetCurrentSpawn().(core.SpawnEvent); ok && h.spawnActive(spawn) {
+		ws.push(WSSpawn, newSpawnInfo(spawn, h.rules.Capture.ModeFor(spawn.Word.Rarity)))
+	}
+}
+
+// rateKeys retourne les clés de débit de la connexion: son adresse IP et, si le
+// joueur s'est authentifié au join, ce joueur (un playerId non vérifié n'est pas une clé)
+func (ws *wsConn) rateKeys() []string {
+	keys := []string{"ip:" + ws.clientIP}
+	if ws.h.tokens != nil {
+		keys = append(keys, "player:"+ws.playerID)
+	}
+	return keys
+}
+
+// reply pousse la réponse d'une opération de rencontre sous le type de message adapté
+func (ws *wsConn) reply(o outcome) {
+	switch body := o.body.(type) {
+	case EncounterResponse:
+		// Combat déjà terminé pour ce spawn: résultat plutôt que défi
+		if info, ok := ws.h.encounters.Lookup(body.ID); ok {
+			if r, done := finished(info); done {
+				ws.push(WSResult, r.body)
+				return
+			}
+		}
+		ws.push(WSChallenge, body)
+	case CaptureResultResponse:
+		if body.Status == "wrong" || body.Status == "invalid" {
+			ws.push(WSFeedback, body)
+			return
+		}
+		ws.push(WSResult, body)
+	default:
+		ws.push(WSError, body)
+	}
+}
+
+// newUpgrader crée l'upgrader WebSocket; les origines autorisées sont celles du CORS
+// s'il est activé, sinon seule la même origine est acceptée
+func newUpgrader(cfg *config.APIConfig) websocket.Upgrader {
+	u := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+	if !cfg.API.CORS.Enabled {
+		return u
+	}
+	origins := make(map[string]bool, len(cfg.API.CORS.AllowedOrigins))
+	for _, o := range cfg.API.CORS.AllowedOrigins {
+		origins[o] = true
+	}
+	u.CheckOrigin = func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || origins["*"] || origins[origin] {
+			return true
+		}
+		parsed, err := url.Parse(origin)
+		return err == nil && parsed.Host == r.Host
+	}
+	return u
+}
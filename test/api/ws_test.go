@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+// wsTestMessage est un message serveur dont les données restent brutes
+type wsTestMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// dialWS ouvre une connexion WebSocket sur /api/ws
+func dialWS(t *testing.T, ts *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/ws", nil)
+	if err != nil {
+		t.Fatalf("connexion WebSocket: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// exchange envoie req puis retourne le premier message reçu du type attendu,
+// en conservant les autres messages lus entre-temps
+func exchange(t *testing.T, conn *websocket.Conn, req WSRequest, want string, others *[]wsTestMessage) wsTestMessage {
+	t.Helper()
+	if err := conn.WriteJSON(req); err != nil {
+		t.Fatalf("envoi de %s: %v", req.Type, err)
+	}
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg wsTestMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("%s: message %s attendu, lecture: %v", req.Type, want, err)
+		}
+		if msg.Type == want {
+			return msg
+		}
+		if msg.Type == WSError {
+			t.Fatalf("%s: message %s attendu, erreur %s", req.Type, want, msg.Data)
+		}
+		if others != nil {
+			*others = append(*others, msg)
+		}
+	}
+}
+
+func TestWebSocket_Protocol(t *testing.T) {
+	s := rateLimitedServer(100, 2)
+	rules := core.DefaultRules()
+	rules.Attempts = core.AttemptBudget{ByRarity: map[core.Rarity]int{core.Common: 3}}
+	s.SetRules(rules)
+	player, err := s.handlers.playerStore.CreatePlayer("sacha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spawn := core.SpawnEvent{
+		ID:        "s1",
+		Round:     1,
+		Word:      core.Word{ID: "w1", Text: "chat", Rarity: core.Common, Points: 10},
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := s.handlers.spawnStore.AddSpawn(spawn); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+	conn := dialWS(t, ts)
+
+	// Aucune action avant join
+	if msg := exchange(t, conn, WSRequest{Type: WSStartEncounter}, WSError, nil); !strings.Contains(string(msg.Data), "not_joined") {
+		t.Errorf("avant join: %s, attendu not_joined", msg.Data)
+	}
+
+	// join: le spawn actuel est envoyé sans révéler le mot
+	msg := exchange(t, conn, WSRequest{Type: WSJoin, PlayerID: player.ID}, WSSpawn, nil)
+	var info SpawnInfo
+	if err := json.Unmarshal(msg.Data, &info); err != nil || info.SpawnID != "s1" || info.Length != 4 {
+		t.Errorf("spawn = %s, attendu s1 de 4 lettres", msg.Data)
+	}
+	if strings.Contains(string(msg.Data), "chat") {
+		t.Errorf("le spawn révèle le mot: %s", msg.Data)
+	}
+
+	msg = exchange(t, conn, WSRequest{Type: WSStartEncounter}, WSChallenge, nil)
+	var enc EncounterResponse
+	if err := json.Unmarshal(msg.Data, &enc); err != nil || enc.RemainingAttempts != 3 || enc.Word != "" {
+		t.Errorf("défi = %s, attendu 3 tentatives et mot caché", msg.Data)
+	}
+
+	// Mauvaise tentative: retour détaillé, combat toujours en cours
+	msg = exchange(t, conn, WSRequest{Type: WSAttempt, Attempt: "chut"}, WSFeedback, nil)
+	var fb CaptureResultResponse
+	if err := json.Unmarshal(msg.Data, &fb); err != nil || (fb.Status != "wrong" && fb.Status != "invalid") {
+		t.Errorf("retour = %s, attendu wrong ou invalid", msg.Data)
+	}
+
+	// Bonne tentative: résultat puis progression du classement diffusée
+	var others []wsTestMessage
+	msg = exchange(t, conn, WSRequest{Type: WSAttempt, Attempt: "tach"}, WSResult, &others)
+	var result CaptureResultResponse
+	if err := json.Unmarshal(msg.Data, &result); err != nil || result.Status != "captured" || result.XP != 10 {
+		t.Errorf("résultat = %s, attendu captured (+10 XP)", msg.Data)
+	}
+
+	// Budget de tentatives par minute épuisé (2): la 3e est refusée
+	if err := conn.WriteJSON(WSRequest{Type: WSAttempt, Attempt: "tach"}); err != nil {
+		t.Fatal(err)
+	}
+	var limited, delta bool
+	for _, m := range others {
+		delta = delta || m.Type == WSLeaderboardDelta
+	}
+	for !limited {
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var m wsTestMessage
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatalf("3e tentative: erreur rate_limited attendue, lecture: %v", err)
+		}
+		switch {
+		case m.Type == WSLeaderboardDelta:
+			var d LeaderboardDelta
+			if err := json.Unmarshal(m.Data, &d); err != nil || d.PlayerID != player.ID || d.Gained != 10 {
+				t.Errorf("progression = %s, attendu +10 pour %s", m.Data, player.ID)
+			}
+			delta = true
+		case m.Type == WSError:
+			if !strings.Contains(string(m.Data), "rate_limited") {
+				t.Fatalf("3e tentative: %s, attendu rate_limited", m.Data)
+			}
+			limited = true
+		}
+	}
+	if !delta {
+		t.Error("aucune progression du classement reçue après la capture")
+	}
+}
+
+func TestWebSocket_CloseSockets(t *testing.T) {
+	s := newTestServer(nil)
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+	conn := dialWS(t, ts)
+
+	// Garantit que la connexion est recensée avant l'arrêt
+	exchange(t, conn, WSRequest{Type: WSHint}, WSError, nil)
+	s.handlers.CloseSockets()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("arrêt du serveur: fermeture GoingAway attendue, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Package config contient la configuration du serveur HTTP WordMon.
+// Elle regroupe l'adresse d'écoute, les délais, CORS, la journalisation,
+// la limitation de débit et la sécurité de l'API.
+package config
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// APIConfig contient la configuration de l'API HTTP (configs/api.yaml).
+// Les durées (readTimeout, writeTimeout, sessionTimeout) sont au format time.ParseDuration.
+type APIConfig struct {
+	API struct {
+		Server struct {
+			Port         int    `yaml:"port" toml:"port" json:"port"`
+			Host         string `yaml:"host" toml:"host" json:"host"`
+			ReadTimeout  string `yaml:"readTimeout" toml:"readTimeout" json:"readTimeout"`
+			WriteTimeout string `yaml:"writeTimeout" toml:"writeTimeout" json:"writeTimeout"`
+			// TrustedProxies liste les proxys (IP ou CIDR) dont X-Forwarded-For est cru; vide: aucun
+			TrustedProxies []string `yaml:"trustedProxies" toml:"trustedProxies" json:"trustedProxies"`
+		} `yaml:"server" toml:"server" json:"server"`
+
+		CORS struct {
+			Enabled        bool     `yaml:"enabled" toml:"enabled" json:"enabled"`
+			AllowedOrigins []string `yaml:"allowedOrigins" toml:"allowedOrigins" json:"allowedOrigins"`
+			AllowedMethods []string `yaml:"allowedMethods" toml:"allowedMethods" json:"allowedMethods"`
+			AllowedHeaders []string `yaml:"allowedHeaders" toml:"allowedHeaders" json:"allowedHeaders"`
+		} `yaml:"cors" toml:"cors" json:"cors"`
+
+		Logging struct {
+			Level  string `yaml:"level" toml:"level" json:"level"`
+			Format string `yaml:"format" toml:"format" json:"format"`
+			Output string `yaml:"output" toml:"output" json:"output"`
+		} `yaml:"logging" toml:"logging" json:"logging"`
+
+		RateLimit struct {
+			Enabled           bool `yaml:"enabled" toml:"enabled" json:"enabled"`
+			RequestsPerMinute int  `yaml:"requestsPerMinute" toml:"requestsPerMinute" json:"requestsPerMinute"`
+			AttemptsPerMinute int  `yaml:"attemptsPerMinute" toml:"attemptsPerMinute" json:"attemptsPerMinute"`
+		} `yaml:"rateLimit" toml:"rateLimit" json:"rateLimit"`
+
+		Security struct {
+			EnableAuth     bool   `yaml:"enableAuth" toml:"enableAuth" json:"enableAuth"`
+			JWTSecret      string `yaml:"jwtSecret" toml:"jwtSecret" json:"jwtSecret"`
+			SessionTimeout string `yaml:"sessionTimeout" toml:"sessionTimeout" json:"sessionTimeout"`
+		} `yaml:"security" toml:"security" json:"security"`
+	} `yaml:"api" toml:"api" json:"api"`
+}
+
+// Niveaux, formats et sorties de journalisation acceptés.
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+)
+
+var allowedLogLevels = map[string]struct{}{
+	"debug": {},
+	"info":  {},
+	"warn":  {},
+	"error": {},
+}
+
+// DefaultAPIConfig retourne la configuration historique: écoute sur :8080, sans
+// délais, sans CORS, journal texte sur stdout, sans limitation ni authentification.
+func DefaultAPIConfig() *APIConfig {
+	var c APIConfig
+	c.API.Server.Port = 8080
+	c.API.Logging.Level = "info"
+	c.API.Logging.Format = LogFormatText
+	c.API.Logging.Output = LogOutputStdout
+	c.API.Security.SessionTimeout = DefaultSessionTimeout
+	return &c
+}
+
+// Addr retourne l'adresse d'écoute host:port (host vide: toutes les interfaces).
+func (c APIConfig) Addr() string {
+	return net.JoinHostPort(c.API.Server.Host, strconv.Itoa(c.API.Server.Port))
+}
+
+// ReadTimeout retourne le délai de lecture d'une requête (0: aucun).
+func (c APIConfig) ReadTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.API.Server.ReadTimeout)
+	return d
+}
+
+// WriteTimeout retourne le délai d'écriture d'une réponse (0: aucun).
+func (c APIConfig) WriteTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.API.Server.WriteTimeout)
+	return d
+}
+
+// AttemptsPerMinute retourne le budget des tentatives de capture, par minute
+// (requestsPerMinute si attemptsPerMinute n'est pas renseigné).
+func (c APIConfig) AttemptsPerMinute() int {
+	if c.API.RateLimit.AttemptsPerMinute > 0 {
+		return c.API.RateLimit.AttemptsPerMinute
+	}
+	return c.API.RateLimit.RequestsPerMinute
+}
+
+// SessionTimeout retourne la durée de validité d'un jeton de session.
+func (c APIConfig) SessionTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.API.Security.SessionTimeout)
+	return d
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	envAPIConfigPath = "WORDMON_API_CONFIG_PATH"
+	envAPIHost       = "WORDMON_API_HOST"
+	envAPIPort       = "WORDMON_API_PORT"
+	envAPIJWTSecret  = "WORDMON_API_JWT_SECRET"
+
+	// MinJWTSecretLen est la longueur minimale du secret de signature des jetons
+	MinJWTSecretLen = 32
+
+	DefaultSessionTimeout = "24h"
+)
+
+func LoadAPIConfig(path string) (*APIConfig, error) {
+	if env := os.Getenv(envAPIConfigPath); env != "" {
+		path = env
+	}
+	if path == "" {
+		return nil, &ValidationError{Section: "api", Problems: []string{"aucun chemin fourni (WORDMON_API_CONFIG_PATH ou argument requis)"}}
+	}
+	if err := mustBeYAMLorTOML(path); err != nil {
+		return nil, err
+	}
+
+	// Valeurs par défaut si manquantes
+	cfg := DefaultAPIConfig()
+	if err := decodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+
+	// Overrides d’environnement
+	if v := os.Getenv(envAPIHost); v != "" {
+		cfg.API.Server.Host = v
+	}
+	if v := os.Getenv(envAPIPort); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, &EnvOverrideError{Var: envAPIPort, Value: v, Reason: "doit être un port entre 1 et 65535"}
+		}
+		cfg.API.Server.Port = port
+	}
+	if v := os.Getenv(envAPIJWTSecret); v != "" {
+		cfg.API.Security.JWTSecret = v
+	}
+
+	// Validation
+	if err := validateAPIConfig(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func validateAPIConfig(c *APIConfig) error {
+	e := newValidationError("api")
+	api := c.API
+
+	// Server
+	if api.Server.Port <= 0 || api.Server.Port > 65535 {
+		e.addf("api.server.port doit être entre 1 et 65535 (actuel %d)", api.Server.Port)
+	}
+	for name, v := range map[string]string{"readTimeout": api.Server.ReadTimeout, "writeTimeout": api.Server.WriteTimeout} {
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err != nil || d < 0 {
+			e.addf("api.server.%s invalide '%s' (durée >= 0 attendue, ex. 30s)", name, v)
+		}
+	}
+	for _, p := range api.Server.TrustedProxies {
+		if net.ParseIP(p) == nil {
+			if _, _, err := net.ParseCIDR(p); err != nil {
+				e.addf("api.server.trustedProxies: '%s' n'est ni une IP ni un CIDR", p)
+			}
+		}
+	}
+
+	// CORS
+	if api.CORS.Enabled {
+		if len(api.CORS.AllowedOrigins) == 0 {
+			e.addf("api.cors.allowedOrigins manquant ou vide alors que CORS est activé")
+		}
+		if len(api.CORS.AllowedMethods) == 0 {
+			e.addf("api.cors.allowedMethods manquant ou vide alors que CORS est activé")
+		}
+	}
+
+	// Logging
+	if _, ok := allowedLogLevels[api.Logging.Level]; !ok {
+		e.addf("api.logging.level inconnu '%s' (attendu debug, info, warn ou error)", api.Logging.Level)
+	}
+	if api.Logging.Format != LogFormatJSON && api.Logging.Format != LogFormatText {
+		e.addf("api.logging.format inconnu '%s' (attendu %s ou %s)", api.Logging.Format, LogFormatJSON, LogFormatText)
+	}
+	if api.Logging.Output != LogOutputStdout && api.Logging.Output != LogOutputStderr {
+		e.addf("api.logging.output inconnu '%s' (attendu %s ou %s)", api.Logging.Output, LogOutputStdout, LogOutputStderr)
+	}
+
+	// RateLimit
+	if api.RateLimit.Enabled && api.RateLimit.RequestsPerMinute <= 0 {
+		e.addf("api.rateLimit.requestsPerMinute doit être > 0 (actuel %d)", api.RateLimit.RequestsPerMinute)
+	}
+	if api.RateLimit.AttemptsPerMinute < 0 {
+		e.addf("api.rateLimit.attemptsPerMinute doit être >= 0 (actuel %d)", api.RateLimit.AttemptsPerMinute)
+	}
+
+	// Security
+	if d, err := time.ParseDuration(api.Security.SessionTimeout); err != nil || d <= 0 {
+		e.addf("api.security.sessionTimeout invalide '%s' (durée > 0 attendue, ex. 24h)", api.Security.SessionTimeout)
+	}
+	if api.Security.EnableAuth && len(api.Security.JWTSecret) < MinJWTSecretLen {
+		e.addf("api.security.jwtSecret doit faire au moins %d caractères quand enableAuth est activé (actuel %d)", MinJWTSecretLen, len(api.Security.JWTSecret))
+	}
+
+	if e.ok() {
+		return nil
+	}
+	return e
+}
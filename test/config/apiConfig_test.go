@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadAPIConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	content := "api:\n  server:\n    port: 9000\n    readTimeout: \"10s\"\n  logging:\n    format: json\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envAPIHost, "0.0.0.0")
+
+	cfg, err := LoadAPIConfig(path)
+	if err != nil {
+		t.Fatalf("LoadAPIConfig ne devrait pas échouer: %v", err)
+	}
+	if cfg.Addr() != "0.0.0.0:9000" {
+		t.Errorf("Addr() = %q, attendu 0.0.0.0:9000", cfg.Addr())
+	}
+	if cfg.ReadTimeout() != 10*time.Second || cfg.WriteTimeout() != 0 {
+		t.Errorf("délais = %v, %v ; attendu 10s, 0", cfg.ReadTimeout(), cfg.WriteTimeout())
+	}
+	if cfg.API.Logging.Level != "info" || cfg.API.Logging.Output != LogOutputStdout || cfg.SessionTimeout() != 24*time.Hour {
+		t.Errorf("valeurs par défaut inattendues: %+v, %v", cfg.API.Logging, cfg.SessionTimeout())
+	}
+
+	cfg.API.RateLimit.RequestsPerMinute = 100
+	if cfg.AttemptsPerMinute() != 100 {
+		t.Errorf("AttemptsPerMinute() = %d, attendu requestsPerMinute (100)", cfg.AttemptsPerMinute())
+	}
+	cfg.API.RateLimit.AttemptsPerMinute = 10
+	if cfg.AttemptsPerMinute() != 10 {
+		t.Errorf("AttemptsPerMinute() = %d, attendu 10", cfg.AttemptsPerMinute())
+	}
+}
+
+func TestAPIConfig_Validation(t *testing.T) {
+	c := DefaultAPIConfig()
+	if err := validateAPIConfig(c); err != nil {
+		t.Fatalf("configuration par défaut refusée: %v", err)
+	}
+
+	c.API.Server.Port = 0
+	c.API.Server.ReadTimeout = "demain"
+	c.API.Server.TrustedProxies = []string{"10.0.0.0/8", "127.0.0.1", "proxy"}
+	c.API.CORS.Enabled = true
+	c.API.Logging.Format = "xml"
+	c.API.RateLimit.Enabled = true
+	c.API.RateLimit.AttemptsPerMinute = -1
+	c.API.Security.SessionTimeout = "0s"
+	c.API.Security.EnableAuth = true
+	c.API.Security.JWTSecret = "court"
+
+	err := validateAPIConfig(c)
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Problems) != 10 {
+		t.Errorf("10 problèmes attendus, got %v", err)
+	}
+}
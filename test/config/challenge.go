@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
 
 const (
 	envChallengesPath = "WORDMON_CHALLENGES_PATH"
@@ -62,8 +66,175 @@ func validateChallenges(c *ChallengesConfig) error {
 		e.addf("aTrou.maxAttempts doit être >= 1 (actuel %d)", c.ATrou.MaxAttempts)
 	}
 
+	// selection: mode connu, raretés et défis existants, poids >= 0
+	sel := c.Selection
+	switch sel.Mode {
+	case "", SelectionFixed:
+		for k, name := range sel.Fixed {
+			if !isAllowedRarity(k) {
+				e.addf("selection.fixed: rareté inconnue '%s'", k)
+			}
+			if !core.DefaultRegistry.Has(name) {
+				e.addf("selection.fixed[%s]: défi inconnu '%s'", k, name)
+			}
+		}
+	case SelectionWeighted:
+		if len(sel.Weights) == 0 {
+			e.addf("selection.weights manquant ou vide (mode weighted)")
+		}
+		for k, weights := range sel.Weights {
+			if !isAllowedRarity(k) {
+				e.addf("selection.weights: rareté inconnue '%s'", k)
+			}
+			sum := 0
+			for name, w := range weights {
+				if !core.DefaultRegistry.Has(name) {
+					e.addf("selection.weights[%s]: défi inconnu '%s'", k, name)
+				}
+				if w < 0 {
+					e.addf("selection.weights[%s][%s] doit être >= 0 (actuel %d)", k, name, w)
+				}
+				sum += w
+			}
+			if sum <= 0 {
+				e.addf("selection.weights[%s]: la somme des poids doit être > 0", k)
+			}
+		}
+	default:
+		e.addf("selection.mode inconnu '%s' (attendu %s ou %s)", sel.Mode, SelectionFixed, SelectionWeighted)
+	}
+
+	// attempts: valeurs >= 0 (0 = non défini), raretés et défis connus
+	if c.Attempts.Default < 0 {
+		e.addf("attempts.default doit être >= 0 (actuel %d)", c.Attempts.Default)
+	}
+	for k, v := range c.Attempts.ByRarity {
+		if !isAllowedRarity(k) {
+			e.addf("attempts.byRarity: rareté inconnue '%s'", k)
+		}
+		if v <= 0 {
+			e.addf("attempts.byRarity[%s] doit être >= 1 (actuel %d)", k, v)
+		}
+	}
+	for name, v := range c.Attempts.ByChallenge {
+		if !core.DefaultRegistry.Has(name) {
+			e.addf("attempts.byChallenge: défi inconnu '%s'", name)
+		}
+		if v <= 0 {
+			e.addf("attempts.byChallenge[%s] doit être >= 1 (actuel %d)", name, v)
+		}
+	}
+
+	if e.ok() {
+		return nil
+	}
+	return e
+}
+
+// ATrouSettings convertit la section aTrou en réglages utilisables par core.
+func (c *ChallengesConfig) ATrouSettings() core.ATrouSettings {
+	revealed := make(map[core.Rarity]int, len(c.ATrou.RevealedLetters))
+	for k, v := range c.ATrou.RevealedLetters {
+		revealed[core.Rarity(k)] = v
+	}
+	return core.ATrouSettings{RevealedLetters: revealed, MaxAttempts: c.ATrou.MaxAttempts}
+}
+
+// AnagramSettings convertit la section anagram en réglages core.
+// lex peut être nil si aucun lexique n'est configuré.
+func (c *ChallengesConfig) AnagramSettings(lex *core.Lexicon) core.AnagramSettings {
+	minLen := make(map[core.Rarity]int, len(c.Anagram.MinLenByRarity))
+	for k, v := range c.Anagram.MinLenByRarity {
+		minLen[core.Rarity(k)] = v
+	}
+	return core.AnagramSettings{
+		MinLenByRarity:       minLen,
+		MustDifferFromSource: c.Anagram.MustDifferFromSource,
+		Lexicon:              lex,
+	}
+}
+
+// Settings construit les réglages typés de tous les défis intégrés.
+func (c *ChallengesConfig) Settings(lex *core.Lexicon) core.ChallengeSettings {
+	return core.ChallengeSettings{
+		Anagram: c.AnagramSettings(lex),
+		ATrou:   c.ATrouSettings(),
+	}
+}
+
+// Rules construit les règles de jeu core à partir de la configuration des défis.
+// lex active la validation des anagrammes par lexique s'il n'est pas nil.
+func (c *ChallengesConfig) Rules(lex *core.Lexicon) *core.Rules {
+	return &core.Rules{
+		Challenges: core.NewBuiltinRegistry(c.Settings(lex)),
+		Selection:  c.SelectionPolicy(),
+		Attempts:   c.AttemptBudget(),
+	}
+}
+
+// AttemptBudget convertit la section attempts en budget de tentatives core.
+func (c *ChallengesConfig) AttemptBudget() core.AttemptBudget {
+	byRarity := make(map[core.Rarity]int, len(c.Attempts.ByRarity))
+	for k, v := range c.Attempts.ByRarity {
+		byRarity[core.Rarity(k)] = v
+	}
+	byChallenge := make(map[string]int, len(c.Attempts.ByChallenge))
+	for k, v := range c.Attempts.ByChallenge {
+		byChallenge[k] = v
+	}
+	return core.AttemptBudget{Default: c.Attempts.Default, ByRarity: byRarity, ByChallenge: byChallenge}
+}
+
+// ValidateWordsForChallenges vérifie que chaque mot du dictionnaire respecte les
+// règles d'anagramme: longueur minimale de sa rareté et, si mustDifferFromSource
+// est actif, au moins une anagramme distincte du mot lui-même.
+func ValidateWordsForChallenges(words []WordEntry, c *ChallengesConfig) error {
+	e := newValidationError("words")
+
+	for i, w := range words {
+		text := stringsTrim(w.Text)
+		if min, ok := c.Anagram.MinLenByRarity[w.Rarity]; ok && len([]rune(text)) < min {
+			e.addf("mot #%d '%s': %d lettre(s), minimum %d pour la rareté %s", i+1, text, len([]rune(text)), min, w.Rarity)
+		}
+		if c.Anagram.MustDifferFromSource && !core.HasDistinctAnagram(text) {
+			e.addf("mot #%d '%s': aucune anagramme distincte possible", i+1, text)
+		}
+	}
+
 	if e.ok() {
 		return nil
 	}
 	return e
 }
+
+// SelectionPolicy convertit la section selection en politique de sélection core.
+func (c *ChallengesConfig) SelectionPolicy() core.SelectionPolicy {
+	if c.Selection.Mode == SelectionWeighted {
+		weights := make(map[core.Rarity]map[string]int, len(c.Selection.Weights))
+		for k, v := range c.Selection.Weights {
+			weights[core.Rarity(k)] = v
+		}
+		return core.WeightedSelection{Weights: weights, Default: core.ChallengeAnagram}
+	}
+	fixed := make(map[core.Rarity]string, len(c.Selection.Fixed))
+	for k, v := range c.Selection.Fixed {
+		fixed[core.Rarity(k)] = v
+	}
+	return core.FixedSelection{ByRarity: fixed, Default: core.ChallengeAnagram}
+}
+
+// MayDrawAnagram indique si la sélection peut attribuer le défi d'anagramme à un mot de la rareté.
+func (c *ChallengesConfig) MayDrawAnagram(rarity string) bool {
+	if c.Selection.Mode == SelectionWeighted {
+		weights := c.Selection.Weights[rarity]
+		total := 0
+		for _, w := range weights {
+			if w > 0 {
+				total += w
+			}
+		}
+		return total == 0 || weights[core.ChallengeAnagram] > 0
+	}
+	name, ok := c.Selection.Fixed[rarity]
+	return !ok || name == core.ChallengeAnagram
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	envDBConfigPath      = "WORDMON_DB_CONFIG_PATH"
+	envDBHost            = "WORDMON_DB_HOST"
+	envDBPort            = "WORDMON_DB_PORT"
+	envDBUser            = "WORDMON_DB_USER"
+	envDBPassword        = "WORDMON_DB_PASSWORD"
+	envDBName            = "WORDMON_DB_NAME"
+	envDBSSLMode         = "WORDMON_DB_SSLMODE"
+	envDBMaxOpenConns    = "WORDMON_DB_MAX_OPEN_CONNS"
+	envDBMaxIdleConns    = "WORDMON_DB_MAX_IDLE_CONNS"
+	envDBConnMaxLifetime = "WORDMON_DB_CONN_MAX_LIFETIME"
+	envDBRetryCount      = "WORDMON_DB_RETRY_COUNT"
+	envDBRetryDelay      = "WORDMON_DB_RETRY_DELAY"
+
+	DefaultDBMaxOpenConns    = 10
+	DefaultDBMaxIdleConns    = 5
+	DefaultDBConnMaxLifetime = "30m"
+	DefaultDBRetryCount      = 30
+	DefaultDBRetryDelay      = "2s"
+)
+
+func LoadDatabaseConfig(path string) (*DatabaseConfig, error) {
+	if env := os.Getenv(envDBConfigPath); env != "" {
+		path = env
+	}
+	if path == "" {
+		return nil, &ValidationError{Section: "database", Problems: []string{"aucun chemin fourni (WORDMON_DB_CONFIG_PATH ou argument requis)"}}
+	}
+	if err := mustBeYAMLorTOML(path); err != nil {
+		return nil, err
+	}
+
+	// 0 connexion inactive est une valeur valide: le défaut est posé avant le
+	// décodage pour qu'une valeur explicite du fichier, même nulle, le remplace
+	var cfg DatabaseConfig
+	cfg.Database.Pool.MaxIdleConns = DefaultDBMaxIdleConns
+	if err := decodeFile(path, &cfg); err != nil {
+		return nil, err
+	}
+
+	// Valeurs par défaut si manquantes
+	db := &cfg.Database
+	if db.SSLMode == "" {
+		db.SSLMode = "disable"
+	}
+	if db.Pool.MaxOpenConns == 0 {
+		db.Pool.MaxOpenConns = DefaultDBMaxOpenConns
+	}
+	if db.Pool.ConnMaxLifetime == "" {
+		db.Pool.ConnMaxLifetime = DefaultDBConnMaxLifetime
+	}
+	if db.Retry.Count == 0 {
+		db.Retry.Count = DefaultDBRetryCount
+	}
+	if db.Retry.Delay == "" {
+		db.Retry.Delay = DefaultDBRetryDelay
+	}
+
+	// Overrides d’environnement
+	if err := applyDatabaseEnv(&cfg); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if err := validateDatabaseConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// applyDatabaseEnv applique les variables WORDMON_DB_* définies.
+func applyDatabaseEnv(c *DatabaseConfig) error {
+	db := &c.Database
+	for env, dst := range map[string]*string{
+		envDBHost:            &db.Host,
+		envDBUser:            &db.User,
+		envDBPassword:        &db.Password,
+		envDBName:            &db.Name,
+		envDBSSLMode:         &db.SSLMode,
+		envDBConnMaxLifetime: &db.Pool.ConnMaxLifetime,
+		envDBRetryDelay:      &db.Retry.Delay,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*dst = v
+		}
+	}
+	// min: plus petite valeur acceptée (0 connexion inactive désactive leur conservation)
+	for env, o := range map[string]struct {
+		dst *int
+		min int
+	}{
+		envDBPort:         {&db.Port, 1},
+		envDBMaxOpenConns: {&db.Pool.MaxOpenConns, 1},
+		envDBMaxIdleConns: {&db.Pool.MaxIdleConns, 0},
+		envDBRetryCount:   {&db.Retry.Count, 1},
+	} {
+		if v := os.Getenv(env); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < o.min {
+				return &EnvOverrideError{Var: env, Value: v, Reason: "doit être un entier >= " + strconv.Itoa(o.min)}
+			}
+			*o.dst = n
+		}
+	}
+	return nil
+}
+
+func validateDatabaseConfig(c *DatabaseConfig) error {
+	e := newValidationError("database")
+	db := c.Database
+
+	// Connexion
+	if db.Host == "" {
+		e.addf("database.host manquant")
+	}
+	if db.Port <= 0 || db.Port > 65535 {
+		e.addf("database.port doit être entre 1 et 65535 (actuel %d)", db.Port)
+	}
+	if db.User == "" {
+		e.addf("database.user manquant")
+	}
+	if db.Name == "" {
+		e.addf("database.name manquant")
+	}
+	if _, ok := allowedSSLModes[db.SSLMode]; !ok {
+		e.addf("database.sslmode inconnu '%s' (attendu disable, require, verify-ca ou verify-full)", db.SSLMode)
+	}
+
+	// Pool
+	if db.Pool.MaxOpenConns <= 0 {
+		e.addf("database.pool.maxOpenConns doit être > 0 (actuel %d)", db.Pool.MaxOpenConns)
+	}
+	if db.Pool.MaxIdleConns < 0 || db.Pool.MaxIdleConns > db.Pool.MaxOpenConns {
+		e.addf("database.pool.maxIdleConns doit être entre 0 et maxOpenConns=%d (actuel %d)", db.Pool.MaxOpenConns, db.Pool.MaxIdleConns)
+	}
+	if d, err := time.ParseDuration(db.Pool.ConnMaxLifetime); err != nil || d < 0 {
+		e.addf("database.pool.connMaxLifetime invalide '%s' (durée >= 0 attendue, ex. 30m)", db.Pool.ConnMaxLifetime)
+	}
+
+	// Retry
+	if db.Retry.Count <= 0 {
+		e.addf("database.retry.count doit être > 0 (actuel %d)", db.Retry.Count)
+	}
+	if d, err := time.ParseDuration(db.Retry.Delay); err != nil || d <= 0 {
+		e.addf("database.retry.delay invalide '%s' (durée > 0 attendue, ex. 2s)", db.Retry.Delay)
+	}
+
+	if e.ok() {
+		return nil
+	}
+	return e
+}
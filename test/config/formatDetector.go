@@ -19,6 +19,7 @@ const (
 	fmtYAML
 	fmtTOML
 	fmtJSON
+	fmtText
 )
 
 func detectFormat(path string) fileFormat {
@@ -30,6 +31,8 @@ func detectFormat(path string) fileFormat {
 		return fmtTOML
 	case ".json":
 		return fmtJSON
+	case ".txt":
+		return fmtText
 	default:
 		return fmtUnknown
 	}
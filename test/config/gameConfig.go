@@ -2,7 +2,11 @@
 // Il définit les paramètres de jeu, les poids de rareté et la configuration des défis.
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
 
 const (
 	RarityCommon    = "Common"
@@ -29,16 +33,46 @@ type GameConfig struct {
 	XPRewards     map[string]int `yaml:"xpRewards" toml:"xpRewards" json:"xpRewards"`
 
 	Spawner struct {
-		IntervalSeconds   int `yaml:"intervalSeconds" toml:"intervalSeconds" json:"intervalSeconds"`
-		AutoFleeAfterSecs int `yaml:"autoFleeAfterSeconds" toml:"autoFleeAfterSeconds" json:"autoFleeAfterSeconds"`
+		IntervalSeconds   int                `yaml:"intervalSeconds" toml:"intervalSeconds" json:"intervalSeconds"`
+		AutoFleeAfterSecs int                `yaml:"autoFleeAfterSeconds" toml:"autoFleeAfterSeconds" json:"autoFleeAfterSeconds"`
+		FleeScaleByRarity map[string]float64 `yaml:"fleeScaleByRarity" toml:"fleeScaleByRarity" json:"fleeScaleByRarity"`
+		CaptureMode       string             `yaml:"captureMode" toml:"captureMode" json:"captureMode"`
+		CaptureByRarity   map[string]string  `yaml:"captureModeByRarity" toml:"captureModeByRarity" json:"captureModeByRarity"`
 	} `yaml:"spawner" toml:"spawner" json:"spawner"`
 
 	Level struct {
-		Base       int `yaml:"base" toml:"base" json:"base"`
-		XPPerLevel int `yaml:"xpPerLevel" toml:"xpPerLevel" json:"xpPerLevel"`
+		Curve      string  `yaml:"curve" toml:"curve" json:"curve"`
+		Base       int     `yaml:"base" toml:"base" json:"base"`
+		XPPerLevel int     `yaml:"xpPerLevel" toml:"xpPerLevel" json:"xpPerLevel"`
+		Factor     float64 `yaml:"factor" toml:"factor" json:"factor"`
+		Table      []int   `yaml:"table" toml:"table" json:"table"`
 	} `yaml:"level" toml:"level" json:"level"`
+
+	Rewards []LevelReward `yaml:"rewards" toml:"rewards" json:"rewards"`
+}
+
+// LevelReward décrit la récompense accordée en atteignant un niveau:
+// objets bonus, raretés débloquées et titre.
+type LevelReward struct {
+	Level          int            `yaml:"level" toml:"level" json:"level"`
+	Items          map[string]int `yaml:"items" toml:"items" json:"items"`
+	UnlockRarities []string       `yaml:"unlockRarities" toml:"unlockRarities" json:"unlockRarities"`
+	Title          string         `yaml:"title" toml:"title" json:"title"`
 }
 
+// Modes de capture d'une apparition (spawner.captureMode et captureModeByRarity).
+const (
+	CaptureModeShared = "shared"
+	CaptureModeRace   = "race"
+)
+
+// Courbes de progression disponibles dans la section level.
+const (
+	LevelCurveLinear      = "linear"
+	LevelCurveExponential = "exponential"
+	LevelCurveTable       = "table"
+)
+
 // SpawnInterval retourne l'intervalle de spawn en tant que Duration.
 // Convertit les secondes de configuration en time.Duration.
 func (g GameConfig) SpawnInterval() time.Duration {
@@ -49,18 +83,104 @@ func (g GameConfig) SpawnInterval() time.Duration {
 	return time.Duration(secs) * time.Second
 }
 
+// FleePolicy retourne la politique de fuite des WordMon (autoFleeAfterSeconds,
+// éventuellement multiplié par rareté). 0 seconde: les mots ne fuient jamais.
+func (g GameConfig) FleePolicy() core.FleePolicy {
+	scale := make(map[core.Rarity]float64, len(g.Spawner.FleeScaleByRarity))
+	for k, v := range g.Spawner.FleeScaleByRarity {
+		scale[core.Rarity(k)] = v
+	}
+	return core.FleePolicy{
+		After:         time.Duration(g.Spawner.AutoFleeAfterSecs) * time.Second,
+		ScaleByRarity: scale,
+	}
+}
+
+// CapturePolicy retourne le mode de capture des apparitions: shared (chacun peut
+// capturer le mot) ou race (le premier à résoudre le défi l'emporte), par rareté.
+func (g GameConfig) CapturePolicy() core.CapturePolicy {
+	byRarity := make(map[core.Rarity]core.CaptureMode, len(g.Spawner.CaptureByRarity))
+	for k, v := range g.Spawner.CaptureByRarity {
+		byRarity[core.Rarity(k)] = core.CaptureMode(v)
+	}
+	return core.CapturePolicy{
+		Default:  core.CaptureMode(g.Spawner.CaptureMode),
+		ByRarity: byRarity,
+	}
+}
+
+// LevelCurve retourne la courbe de progression décrite par la section level.
+// linear: base + XP/xpPerLevel; exponential: xpPerLevel pour le premier niveau,
+// multiplié par factor à chaque niveau; table: seuils d'XP explicites.
+func (g GameConfig) LevelCurve() core.LevelCurve {
+	switch g.Level.Curve {
+	case LevelCurveExponential:
+		return core.ExponentialCurve{Base: g.Level.Base, FirstLevelXP: g.Level.XPPerLevel, Factor: g.Level.Factor}
+	case LevelCurveTable:
+		return core.TableCurve{Base: g.Level.Base, Thresholds: append([]int(nil), g.Level.Table...)}
+	default:
+		return core.LinearCurve{Base: g.Level.Base, XPPerLevel: g.Level.XPPerLevel}
+	}
+}
+
+// RewardTable convertit la section rewards en table de récompenses par niveau.
+func (g GameConfig) RewardTable() core.RewardTable {
+	table := make(core.RewardTable, len(g.Rewards))
+	for _, r := range g.Rewards {
+		reward := core.Reward{Title: r.Title}
+		if len(r.Items) > 0 {
+			reward.Items = make(map[string]int, len(r.Items))
+			for item, n := range r.Items {
+				reward.Items[item] = n
+			}
+		}
+		for _, rar := range r.UnlockRarities {
+			reward.UnlockRarities = append(reward.UnlockRarities, core.Rarity(rar))
+		}
+		table[r.Level] = reward
+	}
+	return table
+}
+
 // ChallengesConfig définit la configuration des différents types de défis.
 // Contient les paramètres pour les anagrammes et les mots à trous.
 type ChallengesConfig struct {
 	Anagram struct {
 		MinLenByRarity       map[string]int `yaml:"minLenByRarity" toml:"minLenByRarity" json:"minLenByRarity"`
 		MustDifferFromSource bool           `yaml:"mustDifferFromSource" toml:"mustDifferFromSource" json:"mustDifferFromSource"`
+		Lexicon              string         `yaml:"lexicon" toml:"lexicon" json:"lexicon"`
 	} `yaml:"anagram" toml:"anagram" json:"anagram"`
 
 	ATrou struct {
 		RevealedLetters map[string]int `yaml:"revealedLetters" toml:"revealedLetters" json:"revealedLetters"`
 		MaxAttempts     int            `yaml:"maxAttempts" toml:"maxAttempts" json:"maxAttempts"`
 	} `yaml:"aTrou" toml:"aTrou" json:"aTrou"`
+
+	Selection ChallengeSelection `yaml:"selection" toml:"selection" json:"selection"`
+	Attempts  AttemptsConfig     `yaml:"attempts" toml:"attempts" json:"attempts"`
+}
+
+// AttemptsConfig définit le nombre de tentatives accordées par combat.
+// ByChallenge prime sur la limite propre au défi, puis ByRarity et Default.
+type AttemptsConfig struct {
+	Default     int            `yaml:"default" toml:"default" json:"default"`
+	ByRarity    map[string]int `yaml:"byRarity" toml:"byRarity" json:"byRarity"`
+	ByChallenge map[string]int `yaml:"byChallenge" toml:"byChallenge" json:"byChallenge"`
+}
+
+// Modes de sélection des défis.
+const (
+	SelectionFixed    = "fixed"
+	SelectionWeighted = "weighted"
+)
+
+// ChallengeSelection décrit comment choisir le défi d'un mot selon sa rareté.
+// En mode "fixed", Fixed associe un défi à chaque rareté; en mode "weighted",
+// Weights donne les poids de chaque défi par rareté. Sans section, l'anagramme est utilisée partout.
+type ChallengeSelection struct {
+	Mode    string                    `yaml:"mode" toml:"mode" json:"mode"`
+	Fixed   map[string]string         `yaml:"fixed" toml:"fixed" json:"fixed"`
+	Weights map[string]map[string]int `yaml:"weights" toml:"weights" json:"weights"`
 }
 
 // WordEntry représente une entrée de mot dans la base de données.
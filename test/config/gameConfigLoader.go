@@ -31,6 +31,12 @@ func LoadGameConfig(path string) (*GameConfig, error) {
 	if cfg.Level.XPPerLevel == 0 {
 		cfg.Level.XPPerLevel = DefaultXPPerLevel
 	}
+	if cfg.Spawner.CaptureMode == "" {
+		cfg.Spawner.CaptureMode = CaptureModeShared
+	}
+	if cfg.Level.Curve == "" {
+		cfg.Level.Curve = LevelCurveLinear
+	}
 
 	// Overrides d’environnement
 	if v := os.Getenv(envSpawnInterval); v != "" {
@@ -91,6 +97,25 @@ func validateGameConfig(c *GameConfig) error {
 	if c.Spawner.AutoFleeAfterSecs < 0 {
 		e.addf("spawner.autoFleeAfterSeconds doit être >= 0 (actuel %d)", c.Spawner.AutoFleeAfterSecs)
 	}
+	for k, v := range c.Spawner.FleeScaleByRarity {
+		if !isAllowedRarity(k) {
+			e.addf("spawner.fleeScaleByRarity: rareté inconnue '%s'", k)
+		}
+		if v <= 0 {
+			e.addf("spawner.fleeScaleByRarity[%s] doit être > 0 (actuel %g)", k, v)
+		}
+	}
+	if c.Spawner.CaptureMode != "" && !isCaptureMode(c.Spawner.CaptureMode) {
+		e.addf("spawner.captureMode inconnu '%s' (attendu %s ou %s)", c.Spawner.CaptureMode, CaptureModeShared, CaptureModeRace)
+	}
+	for k, v := range c.Spawner.CaptureByRarity {
+		if !isAllowedRarity(k) {
+			e.addf("spawner.captureModeByRarity: rareté inconnue '%s'", k)
+		}
+		if !isCaptureMode(v) {
+			e.addf("spawner.captureModeByRarity[%s] inconnu '%s' (attendu %s ou %s)", k, v, CaptureModeShared, CaptureModeRace)
+		}
+	}
 
 	// Level
 	if c.Level.Base <= 0 {
@@ -99,9 +124,59 @@ func validateGameConfig(c *GameConfig) error {
 	if c.Level.XPPerLevel <= 0 {
 		e.addf("level.xpPerLevel doit être > 0 (actuel %d)", c.Level.XPPerLevel)
 	}
+	switch c.Level.Curve {
+	case LevelCurveLinear:
+	case LevelCurveExponential:
+		if c.Level.Factor < 1 {
+			e.addf("level.factor doit être >= 1 pour la courbe exponential (actuel %g)", c.Level.Factor)
+		}
+	case LevelCurveTable:
+		if len(c.Level.Table) == 0 {
+			e.addf("level.table manquant ou vide pour la courbe table")
+		}
+		prev := 0
+		for i, t := range c.Level.Table {
+			if t <= prev {
+				e.addf("level.table[%d] doit être > %d (seuils strictement croissants, actuel %d)", i, prev, t)
+			}
+			prev = t
+		}
+	default:
+		e.addf("level.curve inconnue '%s' (attendu %s, %s ou %s)", c.Level.Curve, LevelCurveLinear, LevelCurveExponential, LevelCurveTable)
+	}
+
+	// Rewards: un niveau au-delà de base, une seule fois, récompense non vide
+	seen := map[int]bool{}
+	for i, r := range c.Rewards {
+		if r.Level <= c.Level.Base {
+			e.addf("rewards[%d].level doit être > level.base (actuel %d)", i, r.Level)
+		}
+		if seen[r.Level] {
+			e.addf("rewards[%d]: niveau %d déjà récompensé", i, r.Level)
+		}
+		seen[r.Level] = true
+		if len(r.Items) == 0 && len(r.UnlockRarities) == 0 && r.Title == "" {
+			e.addf("rewards[%d]: récompense vide pour le niveau %d", i, r.Level)
+		}
+		for item, n := range r.Items {
+			if item == "" || n <= 0 {
+				e.addf("rewards[%d].items[%s] doit être > 0 (actuel %d)", i, item, n)
+			}
+		}
+		for _, rar := range r.UnlockRarities {
+			if !isAllowedRarity(rar) {
+				e.addf("rewards[%d].unlockRarities: rareté inconnue '%s'", i, rar)
+			}
+		}
+	}
 
 	if e.ok() {
 		return nil
 	}
 	return e
 }
+
+// isCaptureMode indique si mode est un mode de capture connu.
+func isCaptureMode(mode string) bool {
+	return mode == CaptureModeShared || mode == CaptureModeRace
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
 )
 
 func TestGameConfig_SpawnInterval(t *testing.T) {
@@ -121,6 +124,7 @@ func TestChallengesConfig_Validation(t *testing.T) {
 		Anagram: struct {
 			MinLenByRarity       map[string]int `yaml:"minLenByRarity" toml:"minLenByRarity" json:"minLenByRarity"`
 			MustDifferFromSource bool           `yaml:"mustDifferFromSource" toml:"mustDifferFromSource" json:"mustDifferFromSource"`
+			Lexicon              string         `yaml:"lexicon" toml:"lexicon" json:"lexicon"`
 		}{
 			MinLenByRarity: map[string]int{
 				"Common":    3,
@@ -181,3 +185,187 @@ func TestWordEntry_Validation(t *testing.T) {
 		t.Errorf("Rareté %s n'est pas autorisée", word.Rarity)
 	}
 }
+
+func TestChallengesConfig_SelectionValidation(t *testing.T) {
+	base := func() ChallengesConfig {
+		var c ChallengesConfig
+		c.Anagram.MinLenByRarity = map[string]int{"Common": 3}
+		c.ATrou.RevealedLetters = map[string]int{"Common": 1}
+		c.ATrou.MaxAttempts = 3
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		selection   ChallengeSelection
+		expectValid bool
+	}{
+		{"Sans sélection", ChallengeSelection{}, true},
+		{"Fixe valide", ChallengeSelection{Mode: "fixed", Fixed: map[string]string{"Rare": "atrou"}}, true},
+		{"Fixe défi inconnu", ChallengeSelection{Mode: "fixed", Fixed: map[string]string{"Rare": "quiz"}}, false},
+		{"Pondéré valide", ChallengeSelection{Mode: "weighted", Weights: map[string]map[string]int{"Common": {"anagram": 1, "atrou": 3}}}, true},
+		{"Pondéré somme nulle", ChallengeSelection{Mode: "weighted", Weights: map[string]map[string]int{"Common": {"anagram": 0}}}, false},
+		{"Pondéré vide", ChallengeSelection{Mode: "weighted"}, false},
+		{"Mode inconnu", ChallengeSelection{Mode: "random"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base()
+			c.Selection = tt.selection
+
+			err := validateChallenges(&c)
+
+			if (err == nil) != tt.expectValid {
+				t.Errorf("validateChallenges() = %v, valide attendu %v", err, tt.expectValid)
+			}
+		})
+	}
+}
+
+func TestValidateWordsForChallenges(t *testing.T) {
+	var c ChallengesConfig
+	c.Anagram.MinLenByRarity = map[string]int{"Common": 3, "Legendary": 7}
+	c.Anagram.MustDifferFromSource = true
+
+	words := []WordEntry{
+		{ID: "c_1", Text: "chat", Rarity: "Common"},
+		{ID: "c_2", Text: "aaa", Rarity: "Common"},
+		{ID: "c_3", Text: "os", Rarity: "Common"},
+		{ID: "l_1", Text: "sphinx", Rarity: "Legendary"},
+	}
+
+	err := ValidateWordsForChallenges(words, &c)
+
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("ValidateWordsForChallenges() = %v, attendu une ValidationError", err)
+	}
+	if len(verr.Problems) != 3 {
+		t.Errorf("%d problème(s) signalé(s), attendu 3 (aaa, os, sphinx): %v", len(verr.Problems), verr.Problems)
+	}
+
+	c.Anagram.MustDifferFromSource = false
+	c.Anagram.MinLenByRarity["Legendary"] = 6
+	if err := ValidateWordsForChallenges(words[:2], &c); err != nil {
+		t.Errorf("ValidateWordsForChallenges() = %v, aucun problème attendu", err)
+	}
+}
+
+func TestGameConfig_FleePolicy(t *testing.T) {
+	config := GameConfig{}
+	config.Spawner.AutoFleeAfterSecs = 4
+	config.Spawner.FleeScaleByRarity = map[string]float64{"Legendary": 2}
+
+	policy := config.FleePolicy()
+
+	if got := policy.For("Common"); got != 4*time.Second {
+		t.Errorf("For(Common) = %v, attendu 4s", got)
+	}
+	if got := policy.For("Legendary"); got != 8*time.Second {
+		t.Errorf("For(Legendary) = %v, attendu 8s", got)
+	}
+}
+
+func TestGameConfig_LevelCurve(t *testing.T) {
+	valid := func() GameConfig {
+		var c GameConfig
+		c.RarityWeights = map[string]int{"Common": 100}
+		c.XPRewards = map[string]int{"Common": 5}
+		c.Spawner.IntervalSeconds = 1
+		c.Level.Base = 1
+		c.Level.XPPerLevel = 100
+		c.Level.Curve = LevelCurveLinear
+		return c
+	}
+
+	c := valid()
+	c.Level.Curve = LevelCurveTable
+	c.Level.Table = []int{50, 150, 400}
+	if err := validateGameConfig(&c); err != nil {
+		t.Fatalf("table valide refusée: %v", err)
+	}
+	if got := c.LevelCurve().Level(160); got != 3 {
+		t.Errorf("table: Level(160) = %d, attendu 3", got)
+	}
+
+	c = valid()
+	c.Level.Curve = LevelCurveExponential
+	c.Level.Factor = 1.5
+	if got := c.LevelCurve().Level(250); got != 3 {
+		t.Errorf("exponential: Level(250) = %d, attendu 3", got)
+	}
+
+	for name, mutate := range map[string]func(*GameConfig){
+		"courbe inconnue":       func(c *GameConfig) { c.Level.Curve = "cubic" },
+		"facteur inférieur à 1": func(c *GameConfig) { c.Level.Curve = LevelCurveExponential; c.Level.Factor = 0.5 },
+		"table vide":            func(c *GameConfig) { c.Level.Curve = LevelCurveTable },
+		"table non croissante":  func(c *GameConfig) { c.Level.Curve = LevelCurveTable; c.Level.Table = []int{100, 100} },
+	} {
+		c := valid()
+		mutate(&c)
+		if err := validateGameConfig(&c); err == nil {
+			t.Errorf("%s: erreur de validation attendue", name)
+		}
+	}
+}
+
+func TestGameConfig_Rewards(t *testing.T) {
+	var c GameConfig
+	c.RarityWeights = map[string]int{"Common": 100}
+	c.XPRewards = map[string]int{"Common": 5}
+	c.Spawner.IntervalSeconds = 1
+	c.Level.Base = 1
+	c.Level.XPPerLevel = 100
+	c.Level.Curve = LevelCurveLinear
+	c.Rewards = []LevelReward{
+		{Level: 2, Items: map[string]int{"potion": 1}},
+		{Level: 5, UnlockRarities: []string{"Legendary"}, Title: "Maître"},
+	}
+	if err := validateGameConfig(&c); err != nil {
+		t.Fatalf("récompenses valides refusées: %v", err)
+	}
+	table := c.RewardTable()
+	if table[2].Items["potion"] != 1 || table[5].Title != "Maître" || table[5].UnlockRarities[0] != "Legendary" {
+		t.Errorf("RewardTable = %+v", table)
+	}
+
+	c.Rewards = append(c.Rewards,
+		LevelReward{Level: 1, Title: "niveau de base"},
+		LevelReward{Level: 2, Title: "doublon"},
+		LevelReward{Level: 3},
+		LevelReward{Level: 4, UnlockRarities: []string{"Mythic"}},
+	)
+	err := validateGameConfig(&c)
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Problems) != 4 {
+		t.Errorf("4 problèmes attendus, got %v", err)
+	}
+}
+
+func TestGameConfig_CapturePolicy(t *testing.T) {
+	var c GameConfig
+	c.RarityWeights = map[string]int{"Common": 100}
+	c.XPRewards = map[string]int{"Common": 5}
+	c.Spawner.IntervalSeconds = 1
+	c.Spawner.CaptureMode = CaptureModeShared
+	c.Spawner.CaptureByRarity = map[string]string{"Legendary": CaptureModeRace}
+	c.Level.Base = 1
+	c.Level.XPPerLevel = 100
+	c.Level.Curve = LevelCurveLinear
+	if err := validateGameConfig(&c); err != nil {
+		t.Fatalf("modes de capture valides refusés: %v", err)
+	}
+	policy := c.CapturePolicy()
+	if policy.ModeFor(core.Legendary) != core.CaptureRace || policy.ModeFor(core.Common) != core.CaptureShared {
+		t.Errorf("CapturePolicy = %+v", policy)
+	}
+
+	c.Spawner.CaptureMode = "first"
+	c.Spawner.CaptureByRarity = map[string]string{"Mythic": CaptureModeRace, "Rare": "solo"}
+	err := validateGameConfig(&c)
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Problems) != 3 {
+		t.Errorf("3 problèmes attendus, got %v", err)
+	}
+}
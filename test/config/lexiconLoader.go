@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
+)
+
+const (
+	envLexiconPath = "WORDMON_LEXICON_PATH"
+)
+
+// LoadLexicon charge une liste de mots réels utilisée pour valider les anagrammes.
+// Le fichier est soit un texte brut (.txt, un mot par ligne, lignes '#' ignorées),
+// soit un tableau JSON de chaînes (.json).
+func LoadLexicon(path string) (*core.Lexicon, error) {
+	if env := os.Getenv(envLexiconPath); env != "" {
+		path = env
+	}
+	if path == "" {
+		return nil, &ValidationError{Section: "lexicon", Problems: []string{"aucun chemin fourni (WORDMON_LEXICON_PATH ou argument requis)"}}
+	}
+
+	var words []string
+	switch detectFormat(path) {
+	case fmtText:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("impossible de lire le fichier %s: %w", path, err)
+		}
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		for sc.Scan() {
+			line := stringsTrim(sc.Text())
+			if line == "" || line[0] == '#' {
+				continue
+			}
+			words = append(words, line)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("erreur de lecture du lexique (%s): %w", path, err)
+		}
+	case fmtJSON:
+		if err := decodeFile(path, &words); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, &UnsupportedFormatError{Path: path}
+	}
+
+	lex := core.NewLexicon(words)
+	if lex.Len() == 0 {
+		return nil, &ValidationError{Section: "lexicon", Problems: []string{"le lexique est vide"}}
+	}
+	return lex, nil
+}
+
+// ValidateWordsForLexicon vérifie que chaque mot pouvant recevoir le défi d'anagramme
+// a au moins une anagramme dans le lexique: sans elle, le défi serait insoluble.
+func ValidateWordsForLexicon(words []WordEntry, c *ChallengesConfig, lex *core.Lexicon) error {
+	e := newValidationError("lexicon")
+
+	for i, w := range words {
+		if c.MayDrawAnagram(w.Rarity) && len(lex.Anagrams(w.Text)) == 0 {
+			e.addf("mot #%d '%s' (%s): aucune anagramme dans le lexique", i+1, w.Text, w.Rarity)
+		}
+	}
+
+	if e.ok() {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateWordsForLexicon(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lexicon.txt")
+	if err := os.WriteFile(path, []byte("# lexique\nchien\nniche\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lex, err := LoadLexicon(path)
+	if err != nil {
+		t.Fatalf("LoadLexicon ne devrait pas échouer: %v", err)
+	}
+
+	words := []WordEntry{{Text: "niche", Rarity: RarityCommon}, {Text: "chat", Rarity: RarityRare}}
+	var vErr *ValidationError
+	if err := ValidateWordsForLexicon(words, &ChallengesConfig{}, lex); !errors.As(err, &vErr) {
+		t.Fatalf("ValidationError attendue, got %v", err)
+	}
+	if vErr.Section != "lexicon" || len(vErr.Problems) != 1 {
+		t.Errorf("problèmes = %v, attendu 1 (chat)", vErr.Problems)
+	}
+
+	// Les mots d'une rareté qui ne tire jamais l'anagramme ne sont pas concernés
+	c := &ChallengesConfig{}
+	c.Selection.Mode = SelectionWeighted
+	c.Selection.Weights = map[string]map[string]int{RarityRare: {"atrou": 1, "anagram": 0}}
+	if err := ValidateWordsForLexicon(words, c, lex); err != nil {
+		t.Errorf("chat (Rare, a-trou seul): aucune erreur attendue, got %v", err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+
+	"github.com/jusgaga/wordmon-go/internal/core"
 )
 
 // GameData contient toutes les configurations chargées
@@ -12,6 +14,7 @@ type GameData struct {
 	Game           *GameConfig
 	Challenges     *ChallengesConfig
 	Words          []WordEntry
+	Lexicon        *core.Lexicon
 	ConfigPath     string
 	WordsPath      string
 	ChallengesPath string
@@ -59,6 +62,13 @@ func LoadAll() (*GameData, error) {
 	} else {
 		log.Printf("[config] spawner.intervalSeconds=%d", game.Spawner.IntervalSeconds)
 	}
+	log.Printf("[config] spawner.captureMode=%s (par rareté: %v)",
+		game.Spawner.CaptureMode, game.Spawner.CaptureByRarity)
+
+	// Log de la progression
+	log.Printf("[config] level: courbe %s (base=%d, xpPerLevel=%d)",
+		game.Level.Curve, game.Level.Base, game.Level.XPPerLevel)
+	log.Printf("[config] rewards: %d niveau(x) récompensé(s)", len(game.Rewards))
 
 	// Charger les défis
 	log.Printf("[config] Chargement des défis depuis: %s", challengesPath)
@@ -66,7 +76,11 @@ func LoadAll() (*GameData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("échec du chargement des défis: %w", err)
 	}
-	log.Println("[config] challenges: anagram + a-trou chargés")
+	mode := challenges.Selection.Mode
+	if mode == "" {
+		mode = SelectionFixed
+	}
+	log.Printf("[config] challenges: anagram + a-trou chargés (sélection %s)", mode)
 
 	// Charger le dictionnaire de mots
 	log.Printf("[config] Chargement du dictionnaire depuis: %s", wordsPath)
@@ -75,6 +89,11 @@ func LoadAll() (*GameData, error) {
 		return nil, fmt.Errorf("échec du chargement du dictionnaire: %w", err)
 	}
 
+	// Vérifier le dictionnaire au regard des règles d'anagramme
+	if err := ValidateWordsForChallenges(words, challenges); err != nil {
+		return nil, fmt.Errorf("dictionnaire incompatible avec les défis: %w", err)
+	}
+
 	// Compter les mots par rareté
 	counts := make(map[string]int)
 	for _, word := range words {
@@ -90,16 +109,81 @@ func LoadAll() (*GameData, error) {
 		}
 	}
 
+	// Charger le lexique des anagrammes (optionnel)
+	var lexicon *core.Lexicon
+	if lexiconPath := getenvOrDefault(envLexiconPath, challenges.Anagram.Lexicon); lexiconPath != "" {
+		log.Printf("[config] Chargement du lexique depuis: %s", lexiconPath)
+		lexicon, err = LoadLexicon(lexiconPath)
+		if err != nil {
+			return nil, fmt.Errorf("échec du chargement du lexique: %w", err)
+		}
+		log.Printf("[config] lexicon: %d mots", lexicon.Len())
+		if err := ValidateWordsForLexicon(words, challenges, lexicon); err != nil {
+			return nil, fmt.Errorf("dictionnaire incompatible avec le lexique: %w", err)
+		}
+	}
+
 	return &GameData{
 		Game:           game,
 		Challenges:     challenges,
 		Words:          words,
+		Lexicon:        lexicon,
 		ConfigPath:     configPath,
 		WordsPath:      wordsPath,
 		ChallengesPath: challengesPath,
 	}, nil
 }
 
+// CoreWords convertit le dictionnaire en mots core, avec les points de xpRewards.
+func (d *GameData) CoreWords() []core.Word {
+	words := make([]core.Word, len(d.Words))
+	for i, w := range d.Words {
+		words[i] = core.Word{
+			ID:     w.ID,
+			Text:   w.Text,
+			Rarity: core.Rarity(w.Rarity),
+			Points: d.Game.XPRewards[w.Rarity],
+		}
+	}
+	return words
+}
+
+// Spawner construit le spawner du jeu à partir de rarityWeights, xpRewards,
+// du délai de fuite et du dictionnaire chargé. rng nil utilise le hasard global.
+func (d *GameData) Spawner(rng core.Rand) (*core.Spawner, error) {
+	weights := make(map[core.Rarity]int, len(d.Game.RarityWeights))
+	for k, v := range d.Game.RarityWeights {
+		weights[core.Rarity(k)] = v
+	}
+	points := make(map[core.Rarity]int, len(d.Game.XPRewards))
+	for k, v := range d.Game.XPRewards {
+		points[core.Rarity(k)] = v
+	}
+	return core.NewSpawner(core.SpawnerSettings{
+		Weights: weights,
+		Points:  points,
+		Words:   d.CoreWords(),
+		Flee:    d.Game.FleePolicy(),
+		Rand:    rng,
+	})
+}
+
+// Rules construit les règles de jeu complètes: défis, tentatives, spawner, progression et mode de capture.
+// Une source rng initialisée avec une graine rend la partie reproductible.
+func (d *GameData) Rules(rng core.Rand) (*core.Rules, error) {
+	spawner, err := d.Spawner(rng)
+	if err != nil {
+		return nil, err
+	}
+	rules := d.Challenges.Rules(d.Lexicon)
+	rules.Spawner = spawner
+	rules.Rand = rng
+	rules.Levels = d.Game.LevelCurve()
+	rules.Rewards = d.Game.RewardTable()
+	rules.Capture = d.Game.CapturePolicy()
+	return rules, nil
+}
+
 // ResolvePath résout un chemin relatif en chemin absolu pour les logs
 func ResolvePath(path string) string {
 	if filepath.IsAbs(path) {
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+)
+
+// useShippedConfigs fait pointer LoadAll sur les fichiers livrés dans configs/
+func useShippedConfigs(t *testing.T) {
+	t.Helper()
+	t.Setenv("WORDMON_CONFIG_PATH", "../../configs/game.yaml")
+	t.Setenv("WORDMON_WORDS_PATH", "../../configs/words.json")
+	t.Setenv("WORDMON_CHALLENGES_PATH", "../../configs/challenges.yaml")
+}
+
+func TestLoadAll_ShippedConfigs(t *testing.T) {
+	useShippedConfigs(t)
+
+	data, err := LoadAll()
+	if err != nil {
+		t.Fatalf("les configurations livrées doivent se charger: %v", err)
+	}
+	if got := data.Challenges.Anagram.MinLenByRarity[RarityLegendary]; got != 7 {
+		t.Errorf("minLenByRarity.Legendary = %d, attendu 7", got)
+	}
+}
+
+func TestLoadAll_ShippedConfigsWithLexicon(t *testing.T) {
+	useShippedConfigs(t)
+	t.Setenv(envLexiconPath, "../../configs/lexicon.txt")
+
+	data, err := LoadAll()
+	if err != nil {
+		t.Fatalf("le lexique livré doit couvrir le dictionnaire livré: %v", err)
+	}
+	if data.Lexicon == nil {
+		t.Fatal("lexique non chargé")
+	}
+	for _, w := range data.Words {
+		if len(data.Lexicon.Anagrams(w.Text)) == 0 {
+			t.Errorf("aucune anagramme du lexique pour %q", w.Text)
+		}
+	}
+}
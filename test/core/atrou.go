@@ -0,0 +1,102 @@
+// Package core contient le défi "à trou" du système WordMon.
+// Le joueur doit retrouver un mot dont seules quelques lettres sont révélées.
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaskRune est le caractère affiché à la place d'une lettre cachée.
+const MaskRune = '_'
+
+// ATrouSettings regroupe les réglages du défi "à trou".
+// RevealedLetters donne le nombre de lettres révélées par rareté,
+// MaxAttempts le nombre de propositions autorisées par rencontre.
+type ATrouSettings struct {
+	RevealedLetters map[Rarity]int
+	MaxAttempts     int
+}
+
+// DefaultATrouSettings retourne les réglages utilisés sans configuration.
+// Les valeurs reprennent celles livrées dans configs/challenges.yaml.
+func DefaultATrouSettings() ATrouSettings {
+	return ATrouSettings{
+		RevealedLetters: map[Rarity]int{Common: 2, Rare: 1, Legendary: 0},
+		MaxAttempts:     4,
+	}
+}
+
+// ATrouChallenge: retrouver un mot dont certaines lettres sont masquées.
+// Le joueur dispose d'un nombre limité de propositions pour une même rencontre.
+type ATrouChallenge struct {
+	settings  ATrouSettings
+	secret    Word
+	mask      []rune
+	remaining int
+	rng       Rand
+}
+
+// NewATrouChallenge crée un défi "à trou" avec les réglages fournis.
+// Un MaxAttempts inférieur à 1 est ramené à une seule tentative.
+func NewATrouChallenge(s ATrouSettings) *ATrouChallenge {
+	if s.MaxAttempts < 1 {
+		s.MaxAttempts = 1
+	}
+	return &ATrouChallenge{settings: s, remaining: s.MaxAttempts}
+}
+
+// Instructions retourne le mot masqué et les tentatives restantes.
+func (a *ATrouChallenge) Instructions() string {
+	return fmt.Sprintf("Complète le mot à trou \"%s\" (%d tentative(s) restante(s))", a.Mask(), a.remaining)
+}
+
+// ResetFor masque le mot selon le nombre de lettres révélées pour la rareté.
+// Les positions révélées sont tirées au hasard et le compteur de tentatives est remis à zéro.
+func (a *ATrouChallenge) ResetFor(r Rarity, w Word) {
+	a.secret = w
+	a.remaining = a.settings.MaxAttempts
+
+	letters := []rune(strings.ToLower(w.Text))
+	a.mask = make([]rune, len(letters))
+	for i := range a.mask {
+		a.mask[i] = MaskRune
+	}
+
+	reveal := a.settings.RevealedLetters[r]
+	if reveal > len(letters) {
+		reveal = len(letters)
+	}
+	for _, i := range perm(orGlobal(a.rng), len(letters))[:reveal] {
+		a.mask[i] = letters[i]
+	}
+}
+
+// UseRand fixe la source de hasard utilisée pour choisir les lettres révélées.
+func (a *ATrouChallenge) UseRand(r Rand) { a.rng = r }
+
+// Mask retourne le mot avec les lettres cachées remplacées par MaskRune.
+func (a *ATrouChallenge) Mask() string { return string(a.mask) }
+
+// RemainingAttempts retourne le nombre de propositions encore autorisées.
+func (a *ATrouChallenge) RemainingAttempts() int { return a.remaining }
+
+// Check vérifie la proposition et décompte une tentative en cas d'échec.
+// Une entrée vide, de mauvaise longueur ou soumise sans tentative restante est refusée sans être décomptée.
+func (a *ATrouChallenge) Check(attempt string) (bool, error) {
+	attempt = strings.ToLower(strings.TrimSpace(attempt))
+	if attempt == "" {
+		return false, &InvalidAttemptError{Input: attempt, Reason: "entrée vide"}
+	}
+	if a.remaining <= 0 {
+		return false, &InvalidAttemptError{Input: attempt, Reason: "plus aucune tentative"}
+	}
+	if len([]rune(attempt)) != len(a.mask) {
+		return false, &InvalidAttemptError{Input: attempt, Reason: fmt.Sprintf("longueur attendue %d", len(a.mask))}
+	}
+	if attempt == strings.ToLower(a.secret.Text) {
+		return true, nil
+	}
+	a.remaining--
+	return false, nil
+}
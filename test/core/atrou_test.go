@@ -0,0 +1,132 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestATrou(revealed, maxAttempts int) *ATrouChallenge {
+	return NewATrouChallenge(ATrouSettings{
+		RevealedLetters: map[Rarity]int{Common: revealed},
+		MaxAttempts:     maxAttempts,
+	})
+}
+
+func TestATrouChallenge_ResetFor_Mask(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		revealed int
+		expected int
+	}{
+		{"Aucune lettre révélée", "pomme", 0, 0},
+		{"Deux lettres révélées", "pomme", 2, 2},
+		{"Plus de lettres que le mot", "chat", 10, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := newTestATrou(tt.revealed, 3)
+			challenge.ResetFor(Common, Word{Text: tt.word, Rarity: Common})
+
+			mask := []rune(challenge.Mask())
+			if len(mask) != len([]rune(tt.word)) {
+				t.Fatalf("Mask() = %q, longueur attendue %d", challenge.Mask(), len(tt.word))
+			}
+			shown := 0
+			for i, r := range mask {
+				if r == MaskRune {
+					continue
+				}
+				shown++
+				if r != rune(tt.word[i]) {
+					t.Errorf("lettre révélée %q en position %d, attendu %q", r, i, tt.word[i])
+				}
+			}
+			if shown != tt.expected {
+				t.Errorf("%d lettre(s) révélée(s), attendu %d", shown, tt.expected)
+			}
+		})
+	}
+}
+
+func TestATrouChallenge_Check(t *testing.T) {
+	challenge := newTestATrou(1, 3)
+	challenge.ResetFor(Common, Word{Text: "Lune"})
+
+	if _, err := challenge.Check(" "); err == nil {
+		t.Error("Check devrait refuser une entrée vide")
+	}
+	if _, err := challenge.Check("lunes"); err == nil {
+		t.Error("Check devrait refuser une mauvaise longueur")
+	}
+	if challenge.RemainingAttempts() != 3 {
+		t.Errorf("RemainingAttempts = %d, attendu 3 (entrées invalides non décomptées)", challenge.RemainingAttempts())
+	}
+
+	ok, err := challenge.Check("lame")
+	if err != nil || ok {
+		t.Errorf("Check(lame) = %v, %v ; attendu false, nil", ok, err)
+	}
+	if challenge.RemainingAttempts() != 2 {
+		t.Errorf("RemainingAttempts = %d, attendu 2", challenge.RemainingAttempts())
+	}
+
+	ok, err = challenge.Check("LUNE")
+	if err != nil || !ok {
+		t.Errorf("Check(LUNE) = %v, %v ; attendu true, nil", ok, err)
+	}
+}
+
+func TestATrouChallenge_Exhausted(t *testing.T) {
+	challenge := newTestATrou(0, 1)
+	challenge.ResetFor(Common, Word{Text: "code"})
+
+	if ok, _ := challenge.Check("node"); ok {
+		t.Fatal("Check(node) ne devrait pas réussir")
+	}
+	if _, err := challenge.Check("code"); err == nil {
+		t.Error("Check devrait refuser une proposition sans tentative restante")
+	}
+
+	challenge.ResetFor(Common, Word{Text: "code"})
+	if challenge.RemainingAttempts() != 1 {
+		t.Errorf("ResetFor devrait restaurer les tentatives, got %d", challenge.RemainingAttempts())
+	}
+}
+
+func TestATrouChallenge_Instructions(t *testing.T) {
+	challenge := newTestATrou(0, 4)
+	challenge.ResetFor(Common, Word{Text: "chat"})
+
+	instructions := challenge.Instructions()
+	if !strings.Contains(instructions, "____") || !strings.Contains(instructions, "4 tentative") {
+		t.Errorf("Instructions = %q, devrait contenir le masque et les tentatives", instructions)
+	}
+}
+
+func TestEncounter_ATrouKeepsBattleUntilExhausted(t *testing.T) {
+	registry := NewChallengeRegistry()
+	_ = registry.Register(ChallengeATrou, func() Challenge { return newTestATrou(0, 2) })
+	enc := Encounter{
+		Phase: StateEncounter,
+		Word:  Word{Text: "lune", Rarity: Common},
+		Rules: &Rules{Challenges: registry, Selection: FixedSelection{Default: ChallengeATrou}},
+	}
+
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	if _, err := enc.SubmitAttempt("lame"); err != nil {
+		t.Fatalf("SubmitAttempt: %v", err)
+	}
+	if enc.Phase != StateInBattle {
+		t.Errorf("Phase = %s, attendu IN_BATTLE après un premier échec", enc.Phase)
+	}
+	if _, err := enc.SubmitAttempt("lime"); err != nil {
+		t.Fatalf("SubmitAttempt: %v", err)
+	}
+	if enc.Phase != StateLost {
+		t.Errorf("Phase = %s, attendu LOST sans tentative restante", enc.Phase)
+	}
+}
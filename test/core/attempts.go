@@ -0,0 +1,85 @@
+// Package core contient le budget de tentatives et le retour d'information des combats.
+// Un combat accorde plusieurs propositions selon la rareté et le défi, chaque échec
+// renvoyant un Feedback structuré.
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// AttemptBudget détermine le nombre de tentatives accordées par rencontre.
+// ByChallenge prime, puis la limite propre au défi (AttemptLimiter), puis
+// ByRarity et enfin Default. Sans aucune valeur, une seule tentative est accordée.
+type AttemptBudget struct {
+	Default     int
+	ByRarity    map[Rarity]int
+	ByChallenge map[string]int
+}
+
+// ForRarity retourne le budget associé à une rareté, hors réglage par défi.
+func (b AttemptBudget) ForRarity(r Rarity) int {
+	if n := b.ByRarity[r]; n > 0 {
+		return n
+	}
+	if b.Default > 0 {
+		return b.Default
+	}
+	return 1
+}
+
+// For retourne le budget d'un combat contre le défi name pour la rareté r.
+func (b AttemptBudget) For(name string, ch Challenge, r Rarity) int {
+	if n := b.ByChallenge[name]; n > 0 {
+		return n
+	}
+	if l, ok := ch.(AttemptLimiter); ok && l.RemainingAttempts() > 0 {
+		return l.RemainingAttempts()
+	}
+	return b.ForRarity(r)
+}
+
+// Feedback décrit le résultat d'une tentative.
+// Missing et Extra listent, triées, les lettres manquantes ou en trop par
+// rapport au mot pour les défis qui savent les calculer (Explainer).
+type Feedback struct {
+	Correct   bool
+	Remaining int
+	Missing   string
+	Extra     string
+}
+
+// Explainer est implémenté par les défis capables de détailler un échec.
+type Explainer interface {
+	Explain(attempt string) Feedback
+}
+
+// Explain compare les lettres de la tentative à celles du mot secret.
+func (a *AnagramChallenge) Explain(attempt string) Feedback {
+	missing, extra := LetterDiff(a.secret.Text, attempt)
+	return Feedback{Missing: missing, Extra: extra}
+}
+
+// LetterDiff retourne les lettres de word absentes de attempt (missing) et
+// celles de attempt absentes de word (extra), sans tenir compte de la casse.
+func LetterDiff(word, attempt string) (missing, extra string) {
+	counts := map[rune]int{}
+	for _, r := range strings.ToLower(strings.TrimSpace(word)) {
+		counts[r]++
+	}
+	for _, r := range strings.ToLower(strings.TrimSpace(attempt)) {
+		counts[r]--
+	}
+	var m, x []rune
+	for r, n := range counts {
+		for ; n > 0; n-- {
+			m = append(m, r)
+		}
+		for ; n < 0; n++ {
+			x = append(x, r)
+		}
+	}
+	sort.Slice(m, func(i, j int) bool { return m[i] < m[j] })
+	sort.Slice(x, func(i, j int) bool { return x[i] < x[j] })
+	return string(m), string(x)
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestAttemptBudget_For(t *testing.T) {
+	budget := AttemptBudget{
+		Default:     2,
+		ByRarity:    map[Rarity]int{Legendary: 1},
+		ByChallenge: map[string]int{"fake": 5},
+	}
+	limited := newTestATrou(0, 4)
+
+	tests := []struct {
+		name      string
+		challenge string
+		ch        Challenge
+		rarity    Rarity
+		expected  int
+	}{
+		{"Par défi", "fake", &fakeChallenge{}, Legendary, 5},
+		{"Limite du défi", ChallengeATrou, limited, Legendary, 4},
+		{"Par rareté", ChallengeAnagram, &AnagramChallenge{}, Legendary, 1},
+		{"Par défaut", ChallengeAnagram, &AnagramChallenge{}, Common, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := budget.For(tt.challenge, tt.ch, tt.rarity); got != tt.expected {
+				t.Errorf("For() = %d, attendu %d", got, tt.expected)
+			}
+		})
+	}
+
+	if got := (AttemptBudget{}).ForRarity(Common); got != 1 {
+		t.Errorf("ForRarity() sans réglage = %d, attendu 1", got)
+	}
+}
+
+func TestLetterDiff(t *testing.T) {
+	tests := []struct {
+		word, attempt  string
+		missing, extra string
+	}{
+		{"chat", "tach", "", ""},
+		{"chat", "chut", "a", "u"},
+		{"Pomme", "pome", "m", ""},
+		{"lune", "lunes", "", "s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+"/"+tt.attempt, func(t *testing.T) {
+			missing, extra := LetterDiff(tt.word, tt.attempt)
+			if missing != tt.missing || extra != tt.extra {
+				t.Errorf("LetterDiff = (%q, %q), attendu (%q, %q)", missing, extra, tt.missing, tt.extra)
+			}
+		})
+	}
+}
+
+func TestEncounter_Attempt_Budget(t *testing.T) {
+	enc := Encounter{
+		Phase: StateEncounter,
+		Word:  Word{Text: "chat", Rarity: Common},
+		Rules: &Rules{
+			Challenges: NewBuiltinRegistry(DefaultChallengeSettings()),
+			Selection:  FixedSelection{Default: ChallengeAnagram},
+			Attempts:   AttemptBudget{ByRarity: map[Rarity]int{Common: 3}},
+		},
+	}
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	if enc.AttemptsLeft != 3 {
+		t.Fatalf("AttemptsLeft = %d, attendu 3", enc.AttemptsLeft)
+	}
+
+	fb, err := enc.Attempt("chut")
+	if err != nil {
+		t.Fatalf("Attempt: %v", err)
+	}
+	if fb.Correct || fb.Remaining != 2 || fb.Missing != "a" || fb.Extra != "u" {
+		t.Errorf("Feedback = %+v, attendu échec avec 2 restantes, manque 'a', en trop 'u'", fb)
+	}
+	if enc.Phase != StateInBattle {
+		t.Errorf("Phase = %s, attendu IN_BATTLE", enc.Phase)
+	}
+
+	// une tentative invalide n'est pas décomptée
+	if _, err := enc.Attempt("chat"); err == nil {
+		t.Error("Attempt devrait refuser le mot identique")
+	}
+	if enc.AttemptsLeft != 2 {
+		t.Errorf("AttemptsLeft = %d, attendu 2 après une tentative invalide", enc.AttemptsLeft)
+	}
+
+	if fb, _ := enc.Attempt("tach"); !fb.Correct || enc.Phase != StateWon {
+		t.Errorf("Attempt(tach) = %+v, phase %s ; attendu victoire", fb, enc.Phase)
+	}
+}
+
+func TestEncounter_Attempt_Exhausted(t *testing.T) {
+	enc := Encounter{
+		Phase: StateEncounter,
+		Word:  Word{Text: "chat", Rarity: Common},
+		Rules: &Rules{
+			Challenges: NewBuiltinRegistry(DefaultChallengeSettings()),
+			Selection:  FixedSelection{Default: ChallengeAnagram},
+			Attempts:   AttemptBudget{Default: 2},
+		},
+	}
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+
+	for i, want := range []State{StateInBattle, StateLost} {
+		fb, err := enc.Attempt("zzzz")
+		if err != nil {
+			t.Fatalf("Attempt #%d: %v", i+1, err)
+		}
+		if enc.Phase != want || fb.Remaining != 1-i {
+			t.Errorf("Attempt #%d: phase %s, restantes %d ; attendu %s, %d", i+1, enc.Phase, fb.Remaining, want, 1-i)
+		}
+	}
+}
@@ -3,7 +3,7 @@
 package core
 
 import (
-	"math/rand"
+	"strconv"
 	"strings"
 )
 
@@ -15,36 +15,118 @@ type Challenge interface {
 	ResetFor(r Rarity, word Word)
 }
 
+// AttemptLimiter est implémenté par les défis qui limitent eux-mêmes le nombre
+// de propositions. Cette limite sert de budget au combat (voir AttemptBudget).
+type AttemptLimiter interface {
+	RemainingAttempts() int
+}
+
+// AnagramSettings regroupe les réglages du défi anagramme.
+// MinLenByRarity impose une longueur minimale par rareté, MustDifferFromSource
+// refuse le mot d'origine et, avec un Lexicon, seules les anagrammes qui sont
+// de vrais mots sont acceptées.
+type AnagramSettings struct {
+	MinLenByRarity       map[Rarity]int
+	MustDifferFromSource bool
+	Lexicon              *Lexicon
+}
+
+// DefaultAnagramSettings retourne les réglages utilisés sans configuration.
+func DefaultAnagramSettings() AnagramSettings {
+	return AnagramSettings{
+		MinLenByRarity:       map[Rarity]int{Common: 3, Rare: 5, Legendary: 7},
+		MustDifferFromSource: true,
+	}
+}
+
+// ChallengeSettings regroupe les réglages de tous les défis intégrés.
+// Il est dérivé de la configuration des défis et sert à construire le registre.
+type ChallengeSettings struct {
+	Anagram AnagramSettings
+	ATrou   ATrouSettings
+}
+
+// DefaultChallengeSettings retourne les réglages par défaut de tous les défis.
+func DefaultChallengeSettings() ChallengeSettings {
+	return ChallengeSettings{
+		Anagram: DefaultAnagramSettings(),
+		ATrou:   DefaultATrouSettings(),
+	}
+}
+
 // AnagramChallenge: réussir une anagramme (réarrangement des lettres, différent de l'original).
 // Le joueur doit créer un mot en réarrangeant les lettres du mot secret.
 type AnagramChallenge struct {
-	secret Word
+	settings AnagramSettings
+	secret   Word
+	rarity   Rarity
+}
+
+// NewAnagramChallenge crée un défi anagramme avec les réglages fournis.
+func NewAnagramChallenge(s AnagramSettings) *AnagramChallenge {
+	return &AnagramChallenge{settings: s}
 }
 
 // Instructions retourne les instructions du défi anagramme.
 // Explique au joueur ce qu'il doit faire.
 func (a *AnagramChallenge) Instructions() string {
-	return "Donne un anagramme valide de \"" + a.secret.Text + "\""
+	instr := "Donne un anagramme valide de \"" + a.secret.Text + "\""
+	if a.settings.Lexicon != nil {
+		instr += " (mot du lexique, " + strconv.Itoa(a.SolutionCount()) + " solution(s))"
+	}
+	return instr
+}
+
+// Solutions retourne les anagrammes acceptées du lexique, ou nil sans lexique.
+func (a *AnagramChallenge) Solutions() []string {
+	if a.settings.Lexicon == nil {
+		return nil
+	}
+	return a.settings.Lexicon.Anagrams(a.secret.Text)
+}
+
+// SolutionCount retourne le nombre d'anagrammes acceptées pour le mot secret.
+// Sans lexique, il s'agit de tous les réarrangements distincts du mot.
+// Le mot d'origine est compté lorsque MustDifferFromSource est désactivé.
+func (a *AnagramChallenge) SolutionCount() int {
+	n := distinctPermutations(strings.ToLower(a.secret.Text)) - 1
+	if a.settings.Lexicon != nil {
+		n = len(a.Solutions())
+	}
+	if !a.settings.MustDifferFromSource {
+		n++
+	}
+	return n
 }
 
 // ResetFor initialise le challenge avec un nouveau mot et une rareté.
 // Prépare le challenge pour une nouvelle rencontre.
-func (a *AnagramChallenge) ResetFor(r Rarity, w Word) { a.secret = w }
+func (a *AnagramChallenge) ResetFor(r Rarity, w Word) { a.secret, a.rarity = w, r }
 
 // Check vérifie si la tentative est valide.
-// Valide que l'anagramme est correct et différent du mot original.
+// Valide que l'anagramme est correct, assez long pour la rareté et, selon les
+// réglages, différent du mot original.
 func (a *AnagramChallenge) Check(attempt string) (bool, error) {
 	attempt = strings.ToLower(strings.TrimSpace(attempt))
 	if attempt == "" {
 		return false, &InvalidAttemptError{Input: attempt, Reason: "entrée vide"}
 	}
+	if min := a.settings.MinLenByRarity[a.rarity]; len([]rune(attempt)) < min {
+		return false, &InvalidAttemptError{Input: attempt, Reason: "trop court pour la rareté " + string(a.rarity) + " (minimum " + strconv.Itoa(min) + ")"}
+	}
 	sec := strings.ToLower(a.secret.Text)
 	if attempt == sec {
-		return false, &InvalidAttemptError{Input: attempt, Reason: "identique au mot"}
+		if a.settings.MustDifferFromSource {
+			return false, &InvalidAttemptError{Input: attempt, Reason: "identique au mot"}
+		}
+		return true, nil
 	}
 	if !sameMultiset(sec, attempt) {
 		return false, nil
 	}
+	if a.settings.Lexicon != nil && !a.settings.Lexicon.Contains(attempt) {
+		return false, nil
+	}
 	return true, nil
 }
 
@@ -73,11 +155,18 @@ func sameMultiset(a, b string) bool {
 }
 
 // AutoAttemptFor génère une tentative plausible pour démo (anagramme par shuffle).
-// Crée automatiquement une anagramme valide pour les tests et démonstrations.
+// Crée automatiquement une anagramme valide pour les tests et démonstrations;
+// avec un lexique, une vraie anagramme est choisie quand il en existe.
 func AutoAttemptFor(e Encounter) string {
+	rng := e.rules().rng()
+	if a, ok := e.Challenge.(*AnagramChallenge); ok {
+		if sols := a.Solutions(); len(sols) > 0 {
+			return sols[rng.Intn(len(sols))]
+		}
+	}
 	w := []rune(e.Word.Text)
 	for i := range w {
-		j := rand.Intn(i + 1)
+		j := rng.Intn(i + 1)
 		w[i], w[j] = w[j], w[i]
 	}
 	cand := string(w)
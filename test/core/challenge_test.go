@@ -26,9 +26,8 @@ func TestAnagramChallenge_Check(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			challenge := &AnagramChallenge{
-				secret: Word{Text: tt.secret},
-			}
+			challenge := NewAnagramChallenge(DefaultAnagramSettings())
+			challenge.secret = Word{Text: tt.secret}
 
 			valid, err := challenge.Check(tt.attempt)
 
@@ -121,3 +120,37 @@ func TestAutoAttemptFor(t *testing.T) {
 		t.Errorf("Attempt %q devrait avoir la même longueur que %q", attempt, "test")
 	}
 }
+
+func TestAnagramChallenge_Settings(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    AnagramSettings
+		rarity      Rarity
+		secret      string
+		attempt     string
+		expectValid bool
+		expectError bool
+	}{
+		{"Identique autorisé", AnagramSettings{}, Common, "chat", "chat", true, false},
+		{"Identique interdit", AnagramSettings{MustDifferFromSource: true}, Common, "chat", "chat", false, true},
+		{"Assez long", AnagramSettings{MinLenByRarity: map[Rarity]int{Rare: 5}}, Rare, "phare", "harpe", true, false},
+		{"Trop court pour la rareté", AnagramSettings{MinLenByRarity: map[Rarity]int{Legendary: 7}}, Legendary, "sphinx", "xnihps", false, true},
+		{"Minimum d'une autre rareté", AnagramSettings{MinLenByRarity: map[Rarity]int{Legendary: 7}}, Common, "chat", "tach", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := NewAnagramChallenge(tt.settings)
+			challenge.ResetFor(tt.rarity, Word{Text: tt.secret, Rarity: tt.rarity})
+
+			valid, err := challenge.Check(tt.attempt)
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("Check erreur = %v, erreur attendue %v", err, tt.expectError)
+			}
+			if valid != tt.expectValid {
+				t.Errorf("Validité = %v, attendu %v", valid, tt.expectValid)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package core contient l'horloge injectable du jeu WordMon.
+// Elle permet de remplacer le temps réel par une horloge manuelle dans les tests
+// ou pour rejouer une partie.
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock fournit l'heure courante, les délais et les tickers utilisés par core.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker est l'équivalent de time.Ticker pour une Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock est l'horloge réelle, basée sur le paquet time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) NewTicker(d time.Duration) Ticker       { return systemTicker{time.NewTicker(d)} }
+
+type systemTicker struct{ t *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.t.C }
+func (t systemTicker) Stop()               { t.t.Stop() }
+
+// FakeClock est une horloge manuelle: le temps n'avance qu'avec Advance.
+// Les délais et tickers arrivés à échéance sont déclenchés pendant Advance.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeTimer
+}
+
+// fakeTimer est un délai (period nul) ou un ticker enregistré sur une FakeClock.
+type fakeTimer struct {
+	clock  *FakeClock
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// NewFakeClock crée une horloge manuelle positionnée à start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now retourne l'heure courante de l'horloge.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After retourne un canal qui reçoit l'heure une fois d écoulé via Advance.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t.ch
+	}
+	c.add(t)
+	return t.ch
+}
+
+// NewTicker crée un ticker déclenché toutes les d via Advance.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("core: intervalle de ticker non positif")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	c.add(t)
+	return t
+}
+
+// Advance avance l'horloge de d et déclenche les délais et tickers échus.
+// Comme time.Ticker, un ticker dont le canal est plein perd les ticks en trop.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	kept := c.waiters[:0]
+	for _, t := range c.waiters {
+		for !t.at.After(c.now) {
+			select {
+			case t.ch <- t.at:
+			default:
+			}
+			if t.period == 0 {
+				break
+			}
+			t.at = t.at.Add(t.period)
+		}
+		if t.period > 0 || t.at.After(c.now) {
+			kept = append(kept, t)
+		}
+	}
+	c.waiters = kept
+}
+
+// BlockUntil attend que n délais ou tickers soient en attente sur l'horloge.
+// Utile pour synchroniser un test avec une goroutine avant d'appeler Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// add enregistre un délai; c.mu doit être détenu.
+func (c *FakeClock) add(t *fakeTimer) {
+	c.waiters = append(c.waiters, t)
+	c.cond.Broadcast()
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+// Stop retire le ticker de l'horloge.
+func (t *fakeTimer) Stop() {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w == t {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_After(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	ch := clock.After(DefaultBattleTimeout)
+	clock.Advance(DefaultBattleTimeout - time.Millisecond)
+	select {
+	case <-ch:
+		t.Fatal("After ne devrait pas se déclencher avant l'échéance")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case got := <-ch:
+		if want := start.Add(DefaultBattleTimeout); !got.Equal(want) {
+			t.Errorf("After = %v, attendu %v", got, want)
+		}
+	default:
+		t.Fatal("After devrait se déclencher à l'échéance")
+	}
+	if got := clock.Now(); !got.Equal(start.Add(DefaultBattleTimeout)) {
+		t.Errorf("Now = %v", got)
+	}
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ticker := clock.NewTicker(time.Second)
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("tick %d manquant", i+1)
+		}
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Error("un ticker arrêté ne devrait plus se déclencher")
+	default:
+	}
+}
@@ -0,0 +1,58 @@
+// Package core contient la gestion des délais de fuite des WordMon.
+// Chaque apparition porte une échéance après laquelle le mot s'enfuit.
+package core
+
+import "time"
+
+// DefaultBattleTimeout est le délai de combat utilisé quand un spawn n'a pas d'échéance.
+const DefaultBattleTimeout = 5 * time.Second
+
+// FleePolicy calcule le délai avant la fuite d'un mot.
+// After est le délai de base (0 = jamais de fuite automatique) et ScaleByRarity
+// un multiplicateur optionnel par rareté (1 si absent).
+type FleePolicy struct {
+	After         time.Duration
+	ScaleByRarity map[Rarity]float64
+}
+
+// For retourne le délai de fuite pour une rareté, 0 si le mot ne fuit jamais.
+func (f FleePolicy) For(r Rarity) time.Duration {
+	if f.After <= 0 {
+		return 0
+	}
+	if scale, ok := f.ScaleByRarity[r]; ok && scale > 0 {
+		return time.Duration(float64(f.After) * scale)
+	}
+	return f.After
+}
+
+// Deadline retourne l'échéance d'un mot apparu à from, ou l'instant zéro sans fuite.
+func (f FleePolicy) Deadline(r Rarity, from time.Time) time.Time {
+	d := f.For(r)
+	if d <= 0 {
+		return time.Time{}
+	}
+	return from.Add(d)
+}
+
+// Expired indique si l'apparition a dépassé son échéance à l'instant now.
+func (s SpawnEvent) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// Expired indique si la rencontre a dépassé son échéance à l'instant now.
+func (e Encounter) Expired(now time.Time) bool {
+	return !e.Deadline.IsZero() && !now.Before(e.Deadline)
+}
+
+// Expire fait fuir le mot si l'échéance est dépassée et que la rencontre est en cours.
+// Retourne une ExpiredError dans ce cas, nil sinon.
+func (e *Encounter) Expire(now time.Time) error {
+	if !e.Expired(now) {
+		return nil
+	}
+	if e.Phase == StateEncounter || e.Phase == StateInBattle {
+		_ = e.Transition(StateFled)
+	}
+	return &ExpiredError{Word: e.Word.Text, Deadline: e.Deadline}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFleePolicy_For(t *testing.T) {
+	policy := FleePolicy{
+		After:         10 * time.Second,
+		ScaleByRarity: map[Rarity]float64{Legendary: 1.5, Rare: 0},
+	}
+
+	tests := []struct {
+		name     string
+		policy   FleePolicy
+		rarity   Rarity
+		expected time.Duration
+	}{
+		{"Sans multiplicateur", policy, Common, 10 * time.Second},
+		{"Multiplicateur nul ignoré", policy, Rare, 10 * time.Second},
+		{"Multiplicateur légendaire", policy, Legendary, 15 * time.Second},
+		{"Pas de fuite", FleePolicy{}, Common, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.For(tt.rarity); got != tt.expected {
+				t.Errorf("For(%s) = %v, attendu %v", tt.rarity, got, tt.expected)
+			}
+		})
+	}
+
+	now := time.Now()
+	if !(FleePolicy{}).Deadline(Common, now).IsZero() {
+		t.Error("Deadline devrait être zéro sans délai de fuite")
+	}
+	if got := policy.Deadline(Legendary, now); !got.Equal(now.Add(15 * time.Second)) {
+		t.Errorf("Deadline = %v, attendu %v", got, now.Add(15*time.Second))
+	}
+}
+
+func TestSpawnEvent_Expired(t *testing.T) {
+	now := time.Now()
+
+	if (SpawnEvent{}).Expired(now) {
+		t.Error("un spawn sans échéance ne devrait jamais expirer")
+	}
+	if !(SpawnEvent{ExpiresAt: now}).Expired(now) {
+		t.Error("un spawn devrait expirer à son échéance")
+	}
+	if (SpawnEvent{ExpiresAt: now.Add(time.Second)}).Expired(now) {
+		t.Error("un spawn ne devrait pas expirer avant son échéance")
+	}
+}
+
+func TestEncounter_Attempt_Expired(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	rules := DefaultRules()
+	rules.Clock = clock
+	enc := Encounter{
+		Phase:    StateEncounter,
+		Word:     Word{Text: "chat", Rarity: Common},
+		Deadline: clock.Now().Add(DefaultBattleTimeout),
+		Rules:    rules,
+	}
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	clock.Advance(DefaultBattleTimeout)
+
+	_, err := enc.Attempt("tach")
+
+	var expired *ExpiredError
+	if !errors.As(err, &expired) {
+		t.Fatalf("Attempt après échéance = %v, attendu une ExpiredError", err)
+	}
+	if enc.Phase != StateFled {
+		t.Errorf("Phase = %s, attendu FLED", enc.Phase)
+	}
+}
+
+func TestEncounter_BeginBattle_Expired(t *testing.T) {
+	enc := Encounter{
+		Phase:    StateEncounter,
+		Word:     Word{Text: "chat", Rarity: Common},
+		Deadline: time.Now().Add(-time.Second),
+	}
+
+	err := enc.BeginBattle()
+
+	var expired *ExpiredError
+	if !errors.As(err, &expired) || enc.Phase != StateFled {
+		t.Errorf("BeginBattle après échéance = %v, phase %s ; attendu ExpiredError et FLED", err, enc.Phase)
+	}
+}
@@ -34,6 +34,14 @@ func (e Encounter) WordMon() Word { return e.Word }
 // CurrentChallenge retourne le défi actuel de la rencontre.
 func (e Encounter) CurrentChallenge() Challenge { return e.Challenge }
 
+// rules retourne les règles de la rencontre, ou les règles par défaut.
+func (e *Encounter) rules() *Rules {
+	if e.Rules == nil {
+		return DefaultRules()
+	}
+	return e.Rules
+}
+
 // Start démarre une nouvelle rencontre pour un joueur.
 // Initialise le spawner et attend l'apparition d'un mot.
 func (e *Encounter) Start(p *Player, spawnCh chan SpawnEvent, interval time.Duration) error {
@@ -43,68 +51,157 @@ func (e *Encounter) Start(p *Player, spawnCh chan SpawnEvent, interval time.Dura
 	e.Player = p
 	ctx, cancel := context.WithCancel(context.Background())
 	e.Cancel = cancel
-	go StartSpawner(ctx, spawnCh, interval)
+	go StartSpawner(ctx, e.rules().Spawner, spawnCh, interval)
 	ev := <-spawnCh
-	e.Word = ev.Word
-	if e.Word.Text == "" { // bug interne, cas exceptionnel → panic
+	if ev.Word.Text == "" { // bug interne, cas exceptionnel → panic
 		panic("WordMon invalide: mot vide")
 	}
-	e.Phase = StateEncounter
-	return nil
+	return e.Meet(ev)
+}
+
+// Meet fait rencontrer le mot de l'événement et passe en ENCOUNTERED.
+// Depuis IDLE, CAPTURED ou FLED; un combat encore en cours est abandonné
+// (le mot précédent s'enfuit). Un combat gagné ou perdu doit d'abord être résolu.
+func (e *Encounter) Meet(ev SpawnEvent) error {
+	if e.Phase == StateEncounter || e.Phase == StateInBattle {
+		if err := e.Transition(StateFled); err != nil {
+			return err
+		}
+	}
+	if err := e.canGo(StateEncounter); err != nil {
+		return err
+	}
+	e.Word = ev.Word
+	e.Deadline = ev.ExpiresAt
+	e.Challenge, e.ChallengeName, e.AttemptsLeft = nil, "", 0
+	e.record(Event{Type: EventSpawn, Spawn: NewSpawnRecord(ev)})
+	return e.Transition(StateEncounter)
+}
+
+// Reset ramène une rencontre terminée (CAPTURED ou FLED) à l'état IDLE.
+func (e *Encounter) Reset() error {
+	return e.Transition(StateIdle)
 }
 
 // BeginBattle lance le combat en initialisant le défi.
-// Passe de l'état ENCOUNTERED à IN_BATTLE.
+// Passe de l'état ENCOUNTERED à IN_BATTLE. Le défi est choisi par les règles
+// de la rencontre selon le mot rencontré. Après l'échéance, le mot s'enfuit.
 func (e *Encounter) BeginBattle() error {
-	if e.Phase != StateEncounter {
-		return &InvalidStateError{From: string(e.Phase), Expected: string(StateEncounter)}
+	if err := e.canGo(StateInBattle); err != nil {
+		return err
+	}
+	if err := e.Expire(e.rules().clock().Now()); err != nil {
+		return err
+	}
+	ch, name, err := e.rules().NewChallenge(e.Word)
+	if err != nil {
+		return err
 	}
-	ch := &AnagramChallenge{}
-	ch.ResetFor(e.Word.Rarity, e.Word)
 	e.Challenge = ch
-	e.Phase = StateInBattle
-	return nil
+	e.ChallengeName = name
+	e.AttemptsLeft = e.rules().Attempts.For(name, ch, e.Word.Rarity)
+	return e.Transition(StateInBattle)
 }
 
 // SubmitAttempt soumet une tentative de résolution du défi.
-// Vérifie la réponse et met à jour l'état selon le résultat.
+// Raccourci de Attempt qui ne retourne que le succès de la tentative.
 func (e *Encounter) SubmitAttempt(input string) (bool, error) {
+	fb, err := e.Attempt(input)
+	return fb.Correct, err
+}
+
+// Attempt soumet une tentative et retourne un retour d'information structuré.
+// Une réponse correcte passe en WON; un échec décompte une tentative et reste
+// en IN_BATTLE tant que le budget n'est pas épuisé, puis passe en LOST.
+// Une tentative invalide (erreur) n'est pas décomptée. Après l'échéance, le mot
+// s'enfuit (FLED) et une ExpiredError est retournée.
+func (e *Encounter) Attempt(input string) (Feedback, error) {
 	if e.Phase != StateInBattle {
-		return false, &InvalidStateError{From: string(e.Phase), Expected: string(StateInBattle)}
+		return Feedback{}, &InvalidStateError{From: string(e.Phase), Expected: string(StateInBattle)}
+	}
+	if err := e.Expire(e.rules().clock().Now()); err != nil {
+		return Feedback{}, err
 	}
 	ok, err := e.Challenge.Check(input)
 	if err != nil {
-		return false, fmt.Errorf("erreur de tentative: %w", err)
+		e.recordAttempt(input, false, err)
+		return Feedback{Remaining: e.AttemptsLeft}, fmt.Errorf("erreur de tentative: %w", err)
 	}
 	if ok {
-		e.Phase = StateWon
-	} else {
-		e.Phase = StateLost
+		e.recordAttempt(input, true, nil)
+		if err := e.Transition(StateWon); err != nil {
+			return Feedback{}, err
+		}
+		return Feedback{Correct: true, Remaining: e.AttemptsLeft}, nil
+	}
+
+	var fb Feedback
+	if ex, isExplainer := e.Challenge.(Explainer); isExplainer {
+		fb = ex.Explain(input)
+	}
+	e.AttemptsLeft--
+	if l, isLimited := e.Challenge.(AttemptLimiter); isLimited && l.RemainingAttempts() < e.AttemptsLeft {
+		e.AttemptsLeft = l.RemainingAttempts()
+	}
+	if e.AttemptsLeft < 0 {
+		e.AttemptsLeft = 0
+	}
+	e.recordAttempt(input, false, nil)
+	if e.AttemptsLeft == 0 {
+		_ = e.Transition(StateLost)
+	}
+	fb.Correct = false
+	fb.Remaining = e.AttemptsLeft
+	return fb, nil
+}
+
+// recordAttempt journalise une tentative avec le budget restant.
+func (e *Encounter) recordAttempt(input string, correct bool, err error) {
+	rec := &AttemptRecord{
+		Word:      e.Word.Text,
+		Challenge: e.ChallengeName,
+		Input:     input,
+		Correct:   correct,
+		Remaining: e.AttemptsLeft,
+	}
+	if err != nil {
+		rec.Error = err.Error()
 	}
-	return ok, nil
+	e.record(Event{Type: EventAttempt, Attempt: rec})
 }
 
 // Resolve finalise la rencontre selon l'état actuel.
-// Gère la capture en cas de victoire ou la fuite en cas de défaite.
+// WON passe en CAPTURED (capture et XP), LOST en FLED; ces états restent visibles
+// jusqu'au prochain Meet ou Reset. Les niveaux franchis sont conservés dans LevelUps.
+// Capture et XP sont appliqués ensemble: en cas d'erreur, le joueur reste inchangé.
 func (e *Encounter) Resolve() error {
+	e.LevelUps = nil
 	switch e.Phase {
 	case StateWon:
 		_, err := Capture(e.Player, e.Word)
 		if err != nil {
 			return fmt.Errorf("capture: %w", err)
 		}
-		if err := AwardXP(e.Player, e.Word.Points); err != nil {
+		rules := e.rules()
+		ups, err := AwardXPWith(e.Player, e.Word.Points, rules.LevelCurve(), rules.Rewards)
+		if err != nil {
+			// AwardXPWith n'a rien modifié: seule la capture est annulée
+			uncapture(e.Player, e.Word)
 			return fmt.Errorf("xp: %w", err)
 		}
-		e.Phase = StateCaptured
-		// retour à IDLE
-		e.Phase = StateEncounter
-		return nil
+		e.LevelUps = ups
+		e.record(Event{Type: EventCapture, Capture: &CaptureRecord{WordID: e.Word.ID, Word: e.Word.Text, Points: e.Word.Points}})
+		return e.Transition(StateCaptured)
 	case StateLost:
-		e.Phase = StateFled
-		e.Phase = StateEncounter
-		return nil
+		return e.Transition(StateFled)
 	default:
 		return &InvalidStateError{From: string(e.Phase), Expected: "WON ou LOST"}
 	}
 }
+
+// uncapture retire de l'inventaire le mot ajouté par Capture.
+func uncapture(p *Player, w Word) {
+	if p.Inventory[w.Text]--; p.Inventory[w.Text] <= 0 {
+		delete(p.Inventory, w.Text)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -132,7 +133,7 @@ func TestEncounter_SubmitAttempt_InvalidTransition(t *testing.T) {
 
 func TestEncounter_SubmitAttempt_InvalidAttempt(t *testing.T) {
 	enc := Encounter{Phase: StateInBattle}
-	challenge := &AnagramChallenge{}
+	challenge := NewAnagramChallenge(DefaultAnagramSettings())
 	challenge.ResetFor(Common, Word{Text: "test"})
 	enc.Challenge = challenge
 
@@ -161,14 +162,42 @@ func TestEncounter_Resolve_Won(t *testing.T) {
 	if err != nil {
 		t.Errorf("Resolve ne devrait pas retourner d'erreur: %v", err)
 	}
-	if enc.Phase != StateEncounter {
-		t.Errorf("Phase devrait être ENCOUNTERED, got %s", enc.Phase)
+	if enc.Phase != StateCaptured {
+		t.Errorf("Phase devrait être CAPTURED, got %s", enc.Phase)
 	}
 	if player.Inventory["test"] != 1 {
 		t.Error("Mot devrait être ajouté à l'inventaire")
 	}
 }
 
+func TestEncounter_Resolve_Won_XPErrorLeavesPlayerUnchanged(t *testing.T) {
+	player := &Player{Level: 1, Inventory: map[string]int{"test": 1}}
+	enc := Encounter{
+		Phase:  StateWon,
+		Player: player,
+		Word:   Word{Text: "test", Points: -5},
+	}
+
+	err := enc.Resolve()
+
+	var neg *NegativePointsError
+	if !errors.As(err, &neg) {
+		t.Fatalf("Resolve devrait retourner NegativePointsError, got %v", err)
+	}
+	if enc.Phase != StateWon {
+		t.Errorf("Phase devrait rester WON, got %s", enc.Phase)
+	}
+	if player.Inventory["test"] != 1 || player.XP != 0 {
+		t.Errorf("joueur modifié malgré l'erreur: inventaire %v, XP %d", player.Inventory, player.XP)
+	}
+
+	player.Inventory = make(map[string]int)
+	enc.Resolve()
+	if _, ok := player.Inventory["test"]; ok {
+		t.Errorf("capture non annulée: inventaire %v", player.Inventory)
+	}
+}
+
 func TestEncounter_Resolve_Lost(t *testing.T) {
 	enc := Encounter{Phase: StateLost}
 
@@ -177,8 +206,8 @@ func TestEncounter_Resolve_Lost(t *testing.T) {
 	if err != nil {
 		t.Errorf("Resolve ne devrait pas retourner d'erreur: %v", err)
 	}
-	if enc.Phase != StateEncounter {
-		t.Errorf("Phase devrait être ENCOUNTERED, got %s", enc.Phase)
+	if enc.Phase != StateFled {
+		t.Errorf("Phase devrait être FLED, got %s", enc.Phase)
 	}
 }
 
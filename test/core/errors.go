@@ -1,6 +1,9 @@
 package core
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type InvalidStateError struct{ From, Expected string }
 
@@ -8,6 +11,12 @@ func (e *InvalidStateError) Error() string {
 	return fmt.Sprintf("transition interdite: état=%s, attendu=%s", e.From, e.Expected)
 }
 
+type InvalidTransitionError struct{ From, To State }
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("transition interdite: %s → %s", e.From, e.To)
+}
+
 type InvalidAttemptError struct{ Input, Reason string }
 
 func (e *InvalidAttemptError) Error() string {
@@ -25,3 +34,39 @@ type NegativePointsError struct{ Points int }
 func (e *NegativePointsError) Error() string {
 	return fmt.Sprintf("points négatifs interdits (%d)", e.Points)
 }
+
+type ChallengeRegistryError struct{ Name, Reason string }
+
+func (e *ChallengeRegistryError) Error() string {
+	return fmt.Sprintf("registre des défis: %q (%s)", e.Name, e.Reason)
+}
+
+type SpawnerError struct{ Reason string }
+
+func (e *SpawnerError) Error() string {
+	return fmt.Sprintf("spawner invalide (%s)", e.Reason)
+}
+
+type EventLogError struct {
+	Line   int
+	Reason string
+}
+
+func (e *EventLogError) Error() string {
+	return fmt.Sprintf("journal d'événements invalide (entrée %d): %s", e.Line, e.Reason)
+}
+
+type EncounterNotFoundError struct{ ID string }
+
+func (e *EncounterNotFoundError) Error() string {
+	return fmt.Sprintf("rencontre introuvable: %s", e.ID)
+}
+
+type ExpiredError struct {
+	Word     string
+	Deadline time.Time
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("%q s'est enfui (échéance %s dépassée)", e.Word, e.Deadline.Format(time.RFC3339))
+}
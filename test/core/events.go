@@ -0,0 +1,211 @@
+// Package core contient le journal d'événements des rencontres WordMon.
+// Apparitions, transitions, tentatives et captures y sont ajoutées sous forme
+// d'événements typés et versionnés, ce qui permet d'enquêter sur une partie
+// et de reconstruire la progression d'un joueur (Replay).
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventVersion est la version du format des événements écrits par ce code.
+const EventVersion = 1
+
+// EventType identifie la nature d'un événement du journal.
+type EventType string
+
+const (
+	EventSpawn      EventType = "spawn"
+	EventTransition EventType = "transition"
+	EventAttempt    EventType = "attempt"
+	EventCapture    EventType = "capture"
+)
+
+// Event est une entrée du journal. Seul le champ correspondant à Type est renseigné.
+// PlayerID est vide pour les événements globaux (apparition côté serveur).
+type Event struct {
+	Version    int               `json:"v"`
+	Type       EventType         `json:"type"`
+	At         time.Time         `json:"at"`
+	PlayerID   string            `json:"playerId,omitempty"`
+	Spawn      *SpawnRecord      `json:"spawn,omitempty"`
+	Transition *TransitionRecord `json:"transition,omitempty"`
+	Attempt    *AttemptRecord    `json:"attempt,omitempty"`
+	Capture    *CaptureRecord    `json:"capture,omitempty"`
+}
+
+// SpawnRecord décrit l'apparition d'un mot.
+type SpawnRecord struct {
+	ID        string    `json:"id,omitempty"`
+	Round     int       `json:"round"`
+	WordID    string    `json:"wordId,omitempty"`
+	Word      string    `json:"word"`
+	Rarity    Rarity    `json:"rarity"`
+	Points    int       `json:"points"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// TransitionRecord décrit un changement d'état d'une rencontre.
+type TransitionRecord struct {
+	Word string `json:"word"`
+	From State  `json:"from"`
+	To   State  `json:"to"`
+}
+
+// AttemptRecord décrit une tentative, telle que saisie par le joueur.
+// Error contient le motif de refus d'une tentative invalide.
+type AttemptRecord struct {
+	Word      string `json:"word"`
+	Challenge string `json:"challenge,omitempty"`
+	Input     string `json:"input"`
+	Correct   bool   `json:"correct"`
+	Remaining int    `json:"remaining"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CaptureRecord décrit une capture et l'XP qu'elle rapporte.
+type CaptureRecord struct {
+	WordID string `json:"wordId,omitempty"`
+	Word   string `json:"word"`
+	Points int    `json:"points"`
+}
+
+// EventLog est un journal d'événements en ajout seul.
+// Events retourne les événements dans l'ordre d'ajout, filtrés par joueur
+// (tous les événements si playerID est vide).
+type EventLog interface {
+	Append(ev Event) error
+	Events(playerID string) ([]Event, error)
+}
+
+// NewSpawnRecord construit l'enregistrement d'une apparition.
+func NewSpawnRecord(ev SpawnEvent) *SpawnRecord {
+	return &SpawnRecord{
+		ID:        ev.ID,
+		Round:     ev.Round,
+		WordID:    ev.Word.ID,
+		Word:      ev.Word.Text,
+		Rarity:    ev.Word.Rarity,
+		Points:    ev.Word.Points,
+		ExpiresAt: ev.ExpiresAt,
+	}
+}
+
+// FileEventLog est un journal au format JSONL: un événement JSON par ligne.
+// Il est sûr pour un usage concurrent au sein d'un même processus.
+type FileEventLog struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+// NewFileEventLog ouvre (ou crée) le fichier journal en mode ajout.
+func NewFileEventLog(path string) (*FileEventLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("ouverture du journal %s: %w", path, err)
+	}
+	return &FileEventLog{path: path, f: f}, nil
+}
+
+// Append écrit l'événement sur une nouvelle ligne du fichier.
+// Un événement sans version reçoit EventVersion.
+func (l *FileEventLog) Append(ev Event) error {
+	if ev.Version == 0 {
+		ev.Version = EventVersion
+	}
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encodage de l'événement %s: %w", ev.Type, err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("écriture du journal %s: %w", l.path, err)
+	}
+	return nil
+}
+
+// Events relit le fichier et retourne les événements du joueur.
+func (l *FileEventLog) Events(playerID string) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture du journal %s: %w", l.path, err)
+	}
+	defer f.Close()
+
+	var out []Event
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			return nil, &EventLogError{Line: n, Reason: err.Error()}
+		}
+		if playerID == "" || ev.PlayerID == playerID {
+			out = append(out, ev)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("lecture du journal %s: %w", l.path, err)
+	}
+	return out, nil
+}
+
+// Close ferme le fichier journal.
+func (l *FileEventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
+
+// Replay reconstruit l'XP, le niveau, l'inventaire et les récompenses d'un joueur
+// en rejouant ses captures avec la courbe et les récompenses des règles (nil: défaut).
+// Un événement d'une version plus récente que EventVersion est refusé.
+func Replay(events []Event, playerID string, rules *Rules) (Player, error) {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	curve := rules.LevelCurve()
+	p := Player{ID: playerID, Level: curve.Level(0), Inventory: make(map[string]int)}
+	for i, ev := range events {
+		if ev.Version > EventVersion {
+			return p, &EventLogError{Line: i + 1, Reason: fmt.Sprintf("version %d non supportée", ev.Version)}
+		}
+		if ev.PlayerID != playerID || ev.Type != EventCapture || ev.Capture == nil {
+			continue
+		}
+		p.Inventory[ev.Capture.Word]++
+		if _, err := AwardXPWith(&p, ev.Capture.Points, curve, rules.Rewards); err != nil {
+			return p, &EventLogError{Line: i + 1, Reason: err.Error()}
+		}
+	}
+	return p, nil
+}
+
+// record ajoute un événement au journal des règles, s'il existe.
+// Le journal ne doit pas interrompre une partie: une erreur est seulement signalée.
+func (e *Encounter) record(ev Event) {
+	log := e.rules().Events
+	if log == nil {
+		return
+	}
+	ev.Version = EventVersion
+	ev.At = e.rules().clock().Now()
+	if e.Player != nil {
+		ev.PlayerID = e.Player.ID
+	}
+	if err := log.Append(ev); err != nil {
+		fmt.Printf("[events] échec d'écriture de l'événement %s: %v\n", ev.Type, err)
+	}
+}
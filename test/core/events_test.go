@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestEventLog(t *testing.T) *FileEventLog {
+	t.Helper()
+	l, err := NewFileEventLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileEventLog: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func TestEncounter_RecordsEvents(t *testing.T) {
+	log := newTestEventLog(t)
+	rules := DefaultRules()
+	rules.Events = log
+	rules.Clock = NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	player := NewPlayer("Ash")
+	player.ID = "p1"
+	enc := NewEncounter()
+	enc.Rules = rules
+	enc.Player = &player
+
+	if err := enc.Meet(SpawnEvent{Round: 1, Word: Word{Text: "chat", Rarity: Common, Points: 5}}); err != nil {
+		t.Fatalf("Meet: %v", err)
+	}
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	if _, err := enc.SubmitAttempt("tach"); err != nil {
+		t.Fatalf("SubmitAttempt: %v", err)
+	}
+	if err := enc.Resolve(); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	events, err := log.Events("p1")
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	var types []EventType
+	for _, ev := range events {
+		if ev.Version != EventVersion {
+			t.Errorf("version = %d, attendu %d", ev.Version, EventVersion)
+		}
+		types = append(types, ev.Type)
+	}
+	want := []EventType{EventSpawn, EventTransition, EventTransition, EventAttempt, EventTransition, EventCapture, EventTransition}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("événements = %v, attendu %v", types, want)
+	}
+	if a := events[3].Attempt; a.Input != "tach" || !a.Correct {
+		t.Errorf("tentative journalisée = %+v", a)
+	}
+	if other, _ := log.Events("p2"); len(other) != 0 {
+		t.Errorf("aucun événement attendu pour p2, got %d", len(other))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	capture := func(player, word string, points int) Event {
+		return Event{Version: EventVersion, Type: EventCapture, At: at, PlayerID: player,
+			Capture: &CaptureRecord{Word: word, Points: points}}
+	}
+	log := newTestEventLog(t)
+	for _, ev := range []Event{
+		capture("p1", "chat", 60),
+		capture("p2", "dragon", 500),
+		{Version: EventVersion, Type: EventAttempt, At: at, PlayerID: "p1", Attempt: &AttemptRecord{Word: "chien", Input: "niche"}},
+		capture("p1", "chat", 60),
+	} {
+		if err := log.Append(ev); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	events, err := log.Events("")
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+
+	rules := DefaultRules()
+	rules.Rewards = RewardTable{2: {Title: "Apprenti"}}
+	p, err := Replay(events, "p1", rules)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if p.XP != 120 || p.Level != 2 || p.Inventory["chat"] != 2 {
+		t.Errorf("joueur rejoué = XP %d, niveau %d, inventaire %v", p.XP, p.Level, p.Inventory)
+	}
+	if !reflect.DeepEqual(p.Titles, []string{"Apprenti"}) {
+		t.Errorf("titres = %v", p.Titles)
+	}
+
+	future := append(events, Event{Version: EventVersion + 1, Type: EventCapture, PlayerID: "p1"})
+	var logErr *EventLogError
+	if _, err := Replay(future, "p1", nil); !errors.As(err, &logErr) {
+		t.Errorf("Replay d'une version inconnue = %v, attendu une EventLogError", err)
+	}
+}
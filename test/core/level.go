@@ -0,0 +1,116 @@
+// Package core contient les courbes de progression des joueurs WordMon.
+// Une courbe associe un niveau à l'expérience cumulée et indique l'XP requise
+// pour atteindre chaque niveau.
+package core
+
+import "math"
+
+// LevelCurve convertit l'expérience cumulée en niveau.
+// XPForLevel retourne l'XP totale nécessaire pour atteindre level, ou false
+// si ce niveau n'existe pas sur la courbe (niveau maximal dépassé).
+type LevelCurve interface {
+	Level(xp int) int
+	XPForLevel(level int) (int, bool)
+}
+
+// DefaultLevelCurve reproduit la progression historique: 1 + XP/100.
+var DefaultLevelCurve LevelCurve = LinearCurve{Base: 1, XPPerLevel: 100}
+
+// LinearCurve accorde un niveau tous les XPPerLevel points à partir du niveau Base.
+type LinearCurve struct {
+	Base       int
+	XPPerLevel int
+}
+
+// Level retourne Base + xp/XPPerLevel.
+func (c LinearCurve) Level(xp int) int {
+	if xp < 0 || c.XPPerLevel <= 0 {
+		return c.Base
+	}
+	return c.Base + xp/c.XPPerLevel
+}
+
+// XPForLevel retourne (level-Base) * XPPerLevel, 0 pour les niveaux inférieurs à Base.
+func (c LinearCurve) XPForLevel(level int) (int, bool) {
+	if level <= c.Base {
+		return 0, true
+	}
+	return (level - c.Base) * c.XPPerLevel, true
+}
+
+// ExponentialCurve multiplie par Factor l'XP nécessaire à chaque niveau.
+// Passer de Base à Base+1 coûte FirstLevelXP, puis FirstLevelXP*Factor, etc.
+type ExponentialCurve struct {
+	Base         int
+	FirstLevelXP int
+	Factor       float64
+}
+
+// Level retourne le plus haut niveau dont le seuil est atteint par xp.
+func (c ExponentialCurve) Level(xp int) int {
+	level, total, step := c.Base, 0.0, float64(c.FirstLevelXP)
+	for math.Round(step) >= 1 {
+		total += math.Round(step)
+		if total > float64(xp) || total > math.MaxInt32 {
+			break
+		}
+		level++
+		step *= c.Factor
+	}
+	return level
+}
+
+// XPForLevel additionne les paliers de Base jusqu'à level.
+// Un palier nul (Factor < 1) ou un seuil hors des bornes d'un int32 rend le niveau inatteignable.
+func (c ExponentialCurve) XPForLevel(level int) (int, bool) {
+	total, step := 0.0, float64(c.FirstLevelXP)
+	for l := c.Base; l < level; l++ {
+		if math.Round(step) < 1 || total > math.MaxInt32 {
+			return 0, false
+		}
+		total += math.Round(step)
+		step *= c.Factor
+	}
+	if total > math.MaxInt32 {
+		return 0, false
+	}
+	return int(total), true
+}
+
+// TableCurve définit explicitement les seuils de chaque niveau.
+// Thresholds[i] est l'XP totale requise pour le niveau Base+1+i; les seuils
+// sont croissants et le dernier correspond au niveau maximal.
+type TableCurve struct {
+	Base       int
+	Thresholds []int
+}
+
+// Level retourne Base plus le nombre de seuils atteints.
+func (c TableCurve) Level(xp int) int {
+	level := c.Base
+	for _, t := range c.Thresholds {
+		if xp < t {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// XPForLevel retourne le seuil du niveau, false au-delà du niveau maximal.
+func (c TableCurve) XPForLevel(level int) (int, bool) {
+	if level <= c.Base {
+		return 0, true
+	}
+	i := level - c.Base - 1
+	if i >= len(c.Thresholds) {
+		return 0, false
+	}
+	return c.Thresholds[i], true
+}
+
+// NextLevelXP retourne l'XP totale requise pour le niveau suivant celui atteint avec xp.
+// Retourne false quand le joueur a atteint le niveau maximal de la courbe.
+func NextLevelXP(c LevelCurve, xp int) (int, bool) {
+	return c.XPForLevel(c.Level(xp) + 1)
+}
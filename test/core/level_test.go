@@ -0,0 +1,55 @@
+package core
+
+import "testing"
+
+func TestLevelCurves(t *testing.T) {
+	tests := []struct {
+		name    string
+		curve   LevelCurve
+		xp      int
+		level   int
+		next    int
+		hasNext bool
+	}{
+		{"linéaire défaut 0", DefaultLevelCurve, 0, 1, 100, true},
+		{"linéaire défaut 250", DefaultLevelCurve, 250, 3, 300, true},
+		{"linéaire base 0", LinearCurve{Base: 0, XPPerLevel: 50}, 120, 2, 150, true},
+		{"exponentielle début", ExponentialCurve{Base: 1, FirstLevelXP: 100, Factor: 2}, 99, 1, 100, true},
+		{"exponentielle 300", ExponentialCurve{Base: 1, FirstLevelXP: 100, Factor: 2}, 300, 3, 700, true},
+		{"table milieu", TableCurve{Base: 1, Thresholds: []int{10, 50, 200}}, 60, 3, 200, true},
+		{"table maximum", TableCurve{Base: 1, Thresholds: []int{10, 50, 200}}, 500, 4, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.curve.Level(tt.xp); got != tt.level {
+				t.Errorf("Level(%d) = %d, attendu %d", tt.xp, got, tt.level)
+			}
+			next, ok := NextLevelXP(tt.curve, tt.xp)
+			if ok != tt.hasNext || next != tt.next {
+				t.Errorf("NextLevelXP(%d) = %d, %v, attendu %d, %v", tt.xp, next, ok, tt.next, tt.hasNext)
+			}
+		})
+	}
+}
+
+func TestExponentialCurve_DecreasingFactor(t *testing.T) {
+	// des paliers qui tendent vers zéro plafonnent la progression
+	c := ExponentialCurve{Base: 1, FirstLevelXP: 4, Factor: 0.25}
+	if got := c.Level(1000); got != 3 {
+		t.Errorf("Level(1000) = %d, attendu 3", got)
+	}
+	if _, ok := c.XPForLevel(4); ok {
+		t.Error("le niveau 4 devrait être inatteignable")
+	}
+}
+
+func TestAwardXPWith_Curve(t *testing.T) {
+	p := &Player{Level: 1}
+	if _, err := AwardXPWith(p, 60, TableCurve{Base: 1, Thresholds: []int{10, 50}}, nil); err != nil {
+		t.Fatalf("AwardXPWith: %v", err)
+	}
+	if p.XP != 60 || p.Level != 3 {
+		t.Errorf("XP=%d Level=%d, attendu 60 et 3", p.XP, p.Level)
+	}
+}
@@ -0,0 +1,97 @@
+// Package core contient le lexique utilisé pour valider les anagrammes.
+// Un lexique est une liste de mots réels indexés par leur multiset de lettres.
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// Lexicon est un ensemble de mots réels, en minuscules.
+// Il est immuable après sa création et peut être partagé entre défis.
+type Lexicon struct {
+	words       map[string]struct{}
+	bySignature map[string][]string
+}
+
+// NewLexicon construit un lexique à partir d'une liste de mots.
+// Les mots sont normalisés (espaces retirés, minuscules); les vides et doublons sont ignorés.
+func NewLexicon(words []string) *Lexicon {
+	l := &Lexicon{
+		words:       make(map[string]struct{}, len(words)),
+		bySignature: make(map[string][]string),
+	}
+	for _, w := range words {
+		w = normalizeWord(w)
+		if w == "" {
+			continue
+		}
+		if _, dup := l.words[w]; dup {
+			continue
+		}
+		l.words[w] = struct{}{}
+		sig := signature(w)
+		l.bySignature[sig] = append(l.bySignature[sig], w)
+	}
+	for _, group := range l.bySignature {
+		sort.Strings(group)
+	}
+	return l
+}
+
+// Len retourne le nombre de mots du lexique.
+func (l *Lexicon) Len() int { return len(l.words) }
+
+// Contains indique si le mot appartient au lexique.
+func (l *Lexicon) Contains(w string) bool {
+	_, ok := l.words[normalizeWord(w)]
+	return ok
+}
+
+// Anagrams retourne les mots du lexique formés des mêmes lettres que w, w exclu.
+func (l *Lexicon) Anagrams(w string) []string {
+	w = normalizeWord(w)
+	group := l.bySignature[signature(w)]
+	out := make([]string, 0, len(group))
+	for _, cand := range group {
+		if cand != w {
+			out = append(out, cand)
+		}
+	}
+	return out
+}
+
+// normalizeWord met un mot sous la forme utilisée pour les comparaisons.
+func normalizeWord(w string) string {
+	return strings.ToLower(strings.TrimSpace(w))
+}
+
+// signature retourne les lettres du mot triées, identiques pour toutes ses anagrammes.
+func signature(w string) string {
+	r := []rune(w)
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+	return string(r)
+}
+
+// HasDistinctAnagram indique si les lettres de w forment au moins un autre réarrangement.
+// Un mot comme "aaa" n'a aucune anagramme distincte.
+func HasDistinctAnagram(w string) bool {
+	return distinctPermutations(normalizeWord(w)) > 1
+}
+
+// distinctPermutations compte les réarrangements distincts des lettres de w.
+func distinctPermutations(w string) int {
+	counts := map[rune]int{}
+	for _, r := range w {
+		counts[r]++
+	}
+	// produit de coefficients binomiaux: C(n1, n1) * C(n1+n2, n2) * ...
+	total, placed := 1, 0
+	for _, k := range counts {
+		for i := 1; i <= k; i++ {
+			placed++
+			total = total * placed / i
+		}
+	}
+	return total
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestLexicon(t *testing.T) {
+	lex := NewLexicon([]string{"phare", " Harpe ", "harpe", "", "rival", "viral", "chat"})
+
+	if lex.Len() != 5 {
+		t.Errorf("Len() = %d, attendu 5 (vides et doublons ignorés)", lex.Len())
+	}
+	if !lex.Contains("HARPE") || lex.Contains("tach") {
+		t.Error("Contains devrait ignorer la casse et refuser les mots absents")
+	}
+
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{"phare", []string{"harpe"}},
+		{"viral", []string{"rival"}},
+		{"chat", []string{}},
+		{"hpare", []string{"harpe", "phare"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := lex.Anagrams(tt.word)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Anagrams(%q) = %v, attendu %v", tt.word, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Anagrams(%q) = %v, attendu %v", tt.word, got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestDistinctPermutations(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected int
+	}{
+		{"", 1},
+		{"aaa", 1},
+		{"chat", 24},
+		{"pomme", 60},
+		{"magma", 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := distinctPermutations(tt.word); got != tt.expected {
+				t.Errorf("distinctPermutations(%q) = %d, attendu %d", tt.word, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnagramChallenge_Lexicon(t *testing.T) {
+	challenge := NewAnagramChallenge(AnagramSettings{MustDifferFromSource: true, Lexicon: NewLexicon([]string{"phare", "harpe"})})
+	challenge.ResetFor(Rare, Word{Text: "phare", Rarity: Rare})
+
+	if ok, err := challenge.Check("harpe"); err != nil || !ok {
+		t.Errorf("Check(harpe) = %v, %v ; attendu true, nil", ok, err)
+	}
+	if ok, err := challenge.Check("rapeh"); err != nil || ok {
+		t.Errorf("Check(rapeh) = %v, %v ; un mot hors lexique doit échouer", ok, err)
+	}
+	if n := challenge.SolutionCount(); n != 1 {
+		t.Errorf("SolutionCount() = %d, attendu 1", n)
+	}
+
+	enc := Encounter{Word: Word{Text: "phare"}, Challenge: challenge}
+	if got := AutoAttemptFor(enc); got != "harpe" {
+		t.Errorf("AutoAttemptFor = %q, attendu la seule anagramme du lexique", got)
+	}
+}
+
+func TestAnagramChallenge_SolutionCountWithoutLexicon(t *testing.T) {
+	challenge := NewAnagramChallenge(AnagramSettings{MustDifferFromSource: true})
+	challenge.ResetFor(Common, Word{Text: "Chat"})
+
+	if n := challenge.SolutionCount(); n != 23 {
+		t.Errorf("SolutionCount() = %d, attendu 23", n)
+	}
+	if challenge.Solutions() != nil {
+		t.Error("Solutions() devrait être nil sans lexique")
+	}
+}
@@ -0,0 +1,330 @@
+// Package core contient le gestionnaire des rencontres concurrentes WordMon.
+// Il conserve une rencontre active par joueur et sérialise les opérations
+// portant sur une même rencontre, ce qui permet de servir plusieurs joueurs à la fois.
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// EncounterInfo est un instantané en lecture seule d'une rencontre gérée.
+type EncounterInfo struct {
+	ID            string
+	PlayerID      string
+	SpawnID       string
+	Round         int
+	Phase         State
+	Word          Word
+	Deadline      time.Time
+	ChallengeName string
+	Instructions  string
+	AttemptsLeft  int
+	LevelUps      []LevelUp
+}
+
+// EncounterManager détient au plus une rencontre par joueur.
+// Les opérations sur des joueurs différents s'exécutent en parallèle; celles
+// sur un même joueur sont sérialisées. Chaque rencontre reçoit un identifiant
+// unique, changé à chaque nouveau spawn. Il est sûr pour un usage concurrent.
+type EncounterManager struct {
+	rules *Rules
+
+	mu       sync.Mutex
+	byPlayer map[string]*managedEncounter
+	byID     map[string]string // identifiant de rencontre -> joueur
+}
+
+// managedEncounter protège une rencontre et le spawn auquel elle se rapporte.
+// removed est positionné par le nettoyage: l'entrée ne doit plus être utilisée.
+type managedEncounter struct {
+	mu      sync.Mutex
+	id      string
+	enc     Encounter
+	spawn   SpawnEvent
+	removed bool
+}
+
+// NewEncounterManager crée un gestionnaire dont les rencontres suivent les règles fournies.
+// Des règles nil équivalent à DefaultRules().
+func NewEncounterManager(rules *Rules) *EncounterManager {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	return &EncounterManager{
+		rules:    rules,
+		byPlayer: make(map[string]*managedEncounter),
+		byID:     make(map[string]string),
+	}
+}
+
+// Engage place le joueur face au spawn et lance le combat.
+// Si sa rencontre porte déjà sur ce spawn (même identifiant, ou même round et même mot), elle est
+// retournée telle quelle, y compris terminée: un joueur ne combat qu'une fois par spawn.
+func (m *EncounterManager) Engage(p *Player, ev SpawnEvent) (EncounterInfo, error) {
+	me := m.acquire(p.ID, true)
+	defer me.mu.Unlock()
+
+	if me.enc.Phase != "" && sameSpawn(me.spawn, ev) {
+		return me.info(p.ID), nil
+	}
+	if me.enc.Phase == "" || me.enc.Phase == StateWon || me.enc.Phase == StateLost {
+		// rencontre neuve, ou combat jamais résolu: on repart d'une rencontre vierge
+		me.enc = NewEncounter()
+		me.enc.Rules = m.rules
+	}
+	me.enc.Player = p
+	me.spawn = ev
+	m.renew(me, p.ID)
+	if err := me.enc.Meet(ev); err != nil {
+		return me.info(p.ID), err
+	}
+	if err := me.enc.BeginBattle(); err != nil {
+		return me.info(p.ID), err
+	}
+	return me.info(p.ID), nil
+}
+
+// Attempt soumet une tentative dans le combat du joueur.
+// Retourne une InvalidStateError si le joueur n'a aucune rencontre.
+func (m *EncounterManager) Attempt(playerID, input string) (Feedback, EncounterInfo, error) {
+	me := m.acquire(playerID, false)
+	if me == nil {
+		return Feedback{}, EncounterInfo{PlayerID: playerID}, &InvalidStateError{From: string(StateIdle), Expected: string(StateInBattle)}
+	}
+	defer me.mu.Unlock()
+
+	fb, err := me.enc.Attempt(input)
+	return fb, me.info(playerID), err
+}
+
+// AttemptByID soumet une tentative dans la rencontre d'identifiant id.
+// Retourne une EncounterNotFoundError si la rencontre n'existe plus.
+func (m *EncounterManager) AttemptByID(id, input string) (Feedback, EncounterInfo, error) {
+	me, playerID := m.acquireID(id)
+	if me == nil {
+		return Feedback{}, EncounterInfo{ID: id}, &EncounterNotFoundError{ID: id}
+	}
+	defer me.mu.Unlock()
+
+	fb, err := me.enc.Attempt(input)
+	return fb, me.info(playerID), err
+}
+
+// Resolve finalise le combat du joueur (capture ou fuite).
+// p reçoit la capture et l'XP: l'appelant fournit l'état à jour du joueur.
+func (m *EncounterManager) Resolve(p *Player) (EncounterInfo, error) {
+	me := m.acquire(p.ID, false)
+	if me == nil {
+		return EncounterInfo{PlayerID: p.ID}, &InvalidStateError{From: string(StateIdle), Expected: "WON ou LOST"}
+	}
+	defer me.mu.Unlock()
+
+	me.enc.Player = p
+	err := me.enc.Resolve()
+	return me.info(p.ID), err
+}
+
+// ForfeitByID termine sans capture la rencontre d'identifiant id (course perdue).
+func (m *EncounterManager) ForfeitByID(id string) (EncounterInfo, error) {
+	me, playerID := m.acquireID(id)
+	if me == nil {
+		return EncounterInfo{ID: id}, &EncounterNotFoundError{ID: id}
+	}
+	defer me.mu.Unlock()
+
+	err := me.enc.Forfeit()
+	return me.info(playerID), err
+}
+
+// ResolveByID finalise la rencontre d'identifiant id pour le joueur p.
+// p doit être le joueur de la rencontre.
+func (m *EncounterManager) ResolveByID(id string, p *Player) (EncounterInfo, error) {
+	me, playerID := m.acquireID(id)
+	if me == nil {
+		return EncounterInfo{ID: id}, &EncounterNotFoundError{ID: id}
+	}
+	defer me.mu.Unlock()
+	if playerID != p.ID {
+		return me.info(playerID), &EncounterNotFoundError{ID: id}
+	}
+
+	me.enc.Player = p
+	err := me.enc.Resolve()
+	return me.info(playerID), err
+}
+
+// Get retourne un instantané de la rencontre du joueur.
+func (m *EncounterManager) Get(playerID string) (EncounterInfo, bool) {
+	me := m.acquire(playerID, false)
+	if me == nil {
+		return EncounterInfo{}, false
+	}
+	defer me.mu.Unlock()
+	return me.info(playerID), true
+}
+
+// Lookup retourne un instantané de la rencontre d'identifiant id.
+func (m *EncounterManager) Lookup(id string) (EncounterInfo, bool) {
+	me, playerID := m.acquireID(id)
+	if me == nil {
+		return EncounterInfo{}, false
+	}
+	defer me.mu.Unlock()
+	return me.info(playerID), true
+}
+
+// Len retourne le nombre de rencontres détenues.
+func (m *EncounterManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.byPlayer)
+}
+
+// Reap retire les rencontres dont l'échéance est passée à now.
+// Un combat en cours est d'abord marqué comme fui (FLED). Retourne le nombre retiré.
+func (m *EncounterManager) Reap(now time.Time) int {
+	m.mu.Lock()
+	entries := make(map[string]*managedEncounter, len(m.byPlayer))
+	for id, me := range m.byPlayer {
+		entries[id] = me
+	}
+	m.mu.Unlock()
+
+	reaped := 0
+	for id, me := range entries {
+		me.mu.Lock()
+		if !me.removed && me.enc.Expired(now) {
+			_ = me.enc.Expire(now)
+			me.removed = true
+			m.mu.Lock()
+			if m.byPlayer[id] == me {
+				delete(m.byPlayer, id)
+			}
+			delete(m.byID, me.id)
+			m.mu.Unlock()
+			reaped++
+		}
+		me.mu.Unlock()
+	}
+	return reaped
+}
+
+// StartReaper appelle Reap à chaque intervalle, selon l'horloge des règles,
+// jusqu'à l'annulation du contexte.
+func (m *EncounterManager) StartReaper(ctx context.Context, interval time.Duration) {
+	ticker := m.rules.clock().NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C():
+			m.Reap(now)
+		}
+	}
+}
+
+// acquire retourne l'entrée du joueur verrouillée, en la créant si create est vrai.
+// Retourne nil si l'entrée n'existe pas et que create est faux.
+func (m *EncounterManager) acquire(playerID string, create bool) *managedEncounter {
+	for {
+		m.mu.Lock()
+		me, ok := m.byPlayer[playerID]
+		if !ok {
+			if !create {
+				m.mu.Unlock()
+				return nil
+			}
+			me = &managedEncounter{}
+			m.byPlayer[playerID] = me
+		}
+		m.mu.Unlock()
+
+		me.mu.Lock()
+		if !me.removed {
+			return me
+		}
+		// retirée entre-temps par Reap: on recommence avec l'entrée courante
+		me.mu.Unlock()
+	}
+}
+
+// acquireID retourne l'entrée verrouillée de la rencontre d'identifiant id et son joueur.
+// Retourne nil si la rencontre a été remplacée ou retirée.
+func (m *EncounterManager) acquireID(id string) (*managedEncounter, string) {
+	m.mu.Lock()
+	playerID, ok := m.byID[id]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ""
+	}
+	me := m.acquire(playerID, false)
+	if me == nil {
+		return nil, ""
+	}
+	if me.id != id {
+		me.mu.Unlock()
+		return nil, ""
+	}
+	return me, playerID
+}
+
+// renew attribue un nouvel identifiant à la rencontre; me.mu doit être détenu.
+func (m *EncounterManager) renew(me *managedEncounter, playerID string) {
+	id := newID(m.rules.Rand)
+	m.mu.Lock()
+	delete(m.byID, me.id)
+	m.byID[id] = playerID
+	m.mu.Unlock()
+	me.id = id
+}
+
+// newID retourne un identifiant aléatoire de 128 bits, en hexadécimal.
+// Il est tiré de r si une source est fournie (partie reproductible avec une graine),
+// sinon de crypto/rand.
+func newID(r Rand) string {
+	var b [16]byte
+	if r != nil {
+		for i := range b {
+			b[i] = byte(r.Intn(256))
+		}
+		return hex.EncodeToString(b[:])
+	}
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("core: génération d'identifiant impossible: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// info construit l'instantané de la rencontre; me.mu doit être détenu.
+func (me *managedEncounter) info(playerID string) EncounterInfo {
+	info := EncounterInfo{
+		ID:            me.id,
+		PlayerID:      playerID,
+		SpawnID:       me.spawn.ID,
+		Round:         me.spawn.Round,
+		Phase:         me.enc.Phase,
+		Word:          me.enc.Word,
+		Deadline:      me.enc.Deadline,
+		ChallengeName: me.enc.ChallengeName,
+		AttemptsLeft:  me.enc.AttemptsLeft,
+		LevelUps:      append([]LevelUp(nil), me.enc.LevelUps...),
+	}
+	if me.enc.Challenge != nil && me.enc.Phase == StateInBattle {
+		info.Instructions = me.enc.Challenge.Instructions()
+	}
+	return info
+}
+
+// sameSpawn indique si deux événements désignent la même apparition:
+// même identifiant s'ils en ont un, sinon même round et même mot.
+func sameSpawn(a, b SpawnEvent) bool {
+	if a.ID != "" || b.ID != "" {
+		return a.ID == b.ID
+	}
+	return a.Round == b.Round && a.Word.Text == b.Word.Text
+}
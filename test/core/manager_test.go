@@ -0,0 +1,149 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEncounterManager_Flow(t *testing.T) {
+	m := NewEncounterManager(nil)
+	p := NewPlayer("Ash")
+	p.ID = "p1"
+	spawn := SpawnEvent{Round: 1, Word: Word{Text: "chat", Rarity: Common, Points: 5}}
+
+	info, err := m.Engage(&p, spawn)
+	if err != nil {
+		t.Fatalf("Engage: %v", err)
+	}
+	if info.Phase != StateInBattle || info.Instructions == "" {
+		t.Fatalf("Engage = %+v, attendu un combat avec consignes", info)
+	}
+
+	fb, info, err := m.Attempt("p1", "tach")
+	if err != nil || !fb.Correct || info.Phase != StateWon {
+		t.Fatalf("Attempt = %+v, %+v, %v", fb, info, err)
+	}
+	if info, err = m.Resolve(&p); err != nil || info.Phase != StateCaptured {
+		t.Fatalf("Resolve = %+v, %v", info, err)
+	}
+	if p.Inventory["chat"] != 1 || p.XP != 5 {
+		t.Errorf("joueur après capture: %+v", p)
+	}
+
+	// même spawn: la rencontre terminée est conservée, pas de second combat
+	if info, _ = m.Engage(&p, spawn); info.Phase != StateCaptured {
+		t.Errorf("Engage sur le même spawn = %s, attendu CAPTURED", info.Phase)
+	}
+	// nouveau spawn: nouveau combat
+	if info, _ = m.Engage(&p, SpawnEvent{Round: 2, Word: Word{Text: "chien", Rarity: Common}}); info.Phase != StateInBattle {
+		t.Errorf("Engage sur un nouveau spawn = %s, attendu IN_BATTLE", info.Phase)
+	}
+
+	if _, _, err := m.Attempt("inconnu", "x"); err == nil {
+		t.Error("Attempt sans rencontre devrait échouer")
+	}
+}
+
+func TestEncounterManager_Reap(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	rules := DefaultRules()
+	rules.Clock = clock
+	m := NewEncounterManager(rules)
+
+	for i, after := range []time.Duration{time.Second, time.Minute} {
+		p := NewPlayer(fmt.Sprintf("joueur%d", i))
+		p.ID = p.Name
+		ev := SpawnEvent{Round: 1, Word: Word{Text: "chat", Rarity: Common}, ExpiresAt: clock.Now().Add(after)}
+		if _, err := m.Engage(&p, ev); err != nil {
+			t.Fatalf("Engage: %v", err)
+		}
+	}
+
+	clock.Advance(2 * time.Second)
+	if n := m.Reap(clock.Now()); n != 1 {
+		t.Errorf("Reap = %d, attendu 1", n)
+	}
+	if _, ok := m.Get("joueur0"); ok {
+		t.Error("la rencontre expirée devrait être retirée")
+	}
+	if info, ok := m.Get("joueur1"); !ok || info.Phase != StateInBattle {
+		t.Errorf("la rencontre en cours devrait rester, got %+v", info)
+	}
+}
+
+func TestEncounterManager_Concurrent(t *testing.T) {
+	m := NewEncounterManager(nil)
+	const players, perPlayer = 50, 8
+	past := time.Now().Add(-time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < players; i++ {
+		p := NewPlayer(fmt.Sprintf("p%d", i))
+		p.ID = p.Name
+		for j := 0; j < perPlayer; j++ {
+			wg.Add(1)
+			go func(p Player, j int) {
+				defer wg.Done()
+				spawn := SpawnEvent{Round: j % 2, Word: Word{Text: "chat", Rarity: Common, Points: 1}}
+				if _, err := m.Engage(&p, spawn); err != nil {
+					return
+				}
+				if fb, _, err := m.Attempt(p.ID, "tach"); err == nil && fb.Correct {
+					_, _ = m.Resolve(&p)
+				}
+				m.Get(p.ID)
+			}(p, j)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Reap(past)
+			m.Len()
+		}()
+	}
+	wg.Wait()
+
+	if n := m.Len(); n != players {
+		t.Errorf("Len = %d, attendu une rencontre par joueur (%d)", n, players)
+	}
+}
+
+func TestEncounterManager_ByID(t *testing.T) {
+	m := NewEncounterManager(nil)
+	p := NewPlayer("Ash")
+	p.ID = "p1"
+
+	first, err := m.Engage(&p, SpawnEvent{Round: 1, Word: Word{Text: "chat", Rarity: Common, Points: 5}})
+	if err != nil || first.ID == "" {
+		t.Fatalf("Engage = %+v, %v", first, err)
+	}
+	if info, ok := m.Lookup(first.ID); !ok || info.PlayerID != "p1" {
+		t.Fatalf("Lookup = %+v, %v", info, ok)
+	}
+	if fb, _, err := m.AttemptByID(first.ID, "tach"); err != nil || !fb.Correct {
+		t.Fatalf("AttemptByID = %+v, %v", fb, err)
+	}
+	other := NewPlayer("Misty")
+	other.ID = "p2"
+	if _, err := m.ResolveByID(first.ID, &other); err == nil {
+		t.Error("ResolveByID pour un autre joueur devrait échouer")
+	}
+	if info, err := m.ResolveByID(first.ID, &p); err != nil || info.Phase != StateCaptured {
+		t.Fatalf("ResolveByID = %+v, %v", info, err)
+	}
+
+	// un nouveau spawn remplace la rencontre et son identifiant
+	second, _ := m.Engage(&p, SpawnEvent{Round: 2, Word: Word{Text: "chien", Rarity: Common}})
+	if second.ID == first.ID {
+		t.Error("un nouveau spawn devrait changer l'identifiant")
+	}
+	var notFound *EncounterNotFoundError
+	if _, _, err := m.AttemptByID(first.ID, "niche"); !errors.As(err, &notFound) {
+		t.Errorf("AttemptByID sur l'ancien identifiant = %v, attendu une EncounterNotFoundError", err)
+	}
+}
@@ -5,28 +5,45 @@ package core
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"time"
 )
 
-// LevelFromXP calcule un niveau simple: 1 + XP/100.
+// LevelFromXP calcule le niveau avec la courbe par défaut: 1 + XP/100.
 // Le niveau augmente de 1 tous les 100 points d'expérience.
 func LevelFromXP(xp int) int {
-	if xp < 0 {
-		return 1
-	}
-	return 1 + xp/100
+	return DefaultLevelCurve.Level(xp)
+}
+
+// AwardXP ajoute des points et recalcule le niveau avec la courbe par défaut.
+// Retourne un LevelUp par niveau franchi, sans récompense.
+func AwardXP(p *Player, points int) ([]LevelUp, error) {
+	return AwardXPWith(p, points, DefaultLevelCurve, nil)
 }
 
-// AwardXP ajoute des points et recalcule le niveau.
-// Met à jour l'expérience du joueur et recalcule automatiquement son niveau.
-func AwardXP(p *Player, points int) error {
+// AwardXPWith ajoute des points, recalcule le niveau selon la courbe c et
+// applique les récompenses des niveaux franchis. Les LevelUp sont calculés
+// avant toute modification: en cas d'erreur, le joueur reste inchangé.
+// Une courbe nil équivaut à DefaultLevelCurve.
+func AwardXPWith(p *Player, points int, c LevelCurve, rewards RewardTable) ([]LevelUp, error) {
 	if points < 0 {
-		return &NegativePointsError{Points: points}
+		return nil, &NegativePointsError{Points: points}
+	}
+	if c == nil {
+		c = DefaultLevelCurve
+	}
+	xp := p.XP + points
+	level := c.Level(xp)
+	var ups []LevelUp
+	for l := c.Level(p.XP) + 1; l <= level; l++ {
+		ups = append(ups, LevelUp{Level: l, Reward: rewards[l]})
 	}
-	p.XP += points
-	p.Level = LevelFromXP(p.XP)
-	return nil
+
+	p.XP = xp
+	p.Level = level
+	for _, up := range ups {
+		up.Reward.applyTo(p)
+	}
+	return ups, nil
 }
 
 // Capture ajoute le mot à l'inventaire et retourne les points gagnés.
@@ -60,16 +77,17 @@ func PrintPlayer(p Player) {
 
 // StartListen orchestre les rounds en utilisant la machine d'états Encounter.
 // Gère le cycle de jeu principal avec les apparitions de mots et les tentatives de capture.
+// Le hasard et le temps proviennent des règles de la rencontre (Rules.Rand, Rules.Clock).
 func StartListen(ctx context.Context, p *Player, spawns chan SpawnEvent, attempts chan Attempts, interval time.Duration, enc Encounter) {
 	if interval <= 0 {
 		interval = time.Second
 	}
-	ticker := time.NewTicker(interval)
+	// Resolve capture le mot et attribue l'XP au joueur de la rencontre
+	enc.Player = p
+	clock, rng := enc.rules().clock(), enc.rules().rng()
+	ticker := clock.NewTicker(interval)
 	defer ticker.Stop()
 
-	// Timeout de combat par WordMon
-	battleTimeout := 5 * time.Second
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -80,6 +98,16 @@ func StartListen(ctx context.Context, p *Player, spawns chan SpawnEvent, attempt
 				return
 			}
 
+			// Nouveau WordMon rencontré, avec son échéance de fuite
+			if ev.ExpiresAt.IsZero() {
+				ev.ExpiresAt = clock.Now().Add(DefaultBattleTimeout)
+			}
+			if err := enc.Meet(ev); err != nil {
+				fmt.Printf("[Round %d] Rencontre impossible avec %q: %v\n", ev.Round, ev.Word.Text, err)
+				continue
+			}
+			battleTimeout := enc.Deadline.Sub(clock.Now())
+
 			// Démarrage du combat (IN_BATTLE)
 			if err := enc.BeginBattle(); err != nil {
 				fmt.Printf("[Round %d] Erreur BeginBattle pour %q: %v\n", ev.Round, enc.Word.Text, err)
@@ -90,46 +118,48 @@ func StartListen(ctx context.Context, p *Player, spawns chan SpawnEvent, attempt
 			fmt.Printf("[Round %d] Combat lancé contre %q → état=%s\n", ev.Round, enc.Word.Text, enc.State())
 
 			// Le joueur décide éventuellement de tenter la capture
-			if rand.Intn(100) < 80 {
+			if rng.Intn(100) < 80 {
 				fmt.Printf("[%s] tente: %q (round %d)\n", p.Name, ev.Word.Text, ev.Round)
 
 				test := AutoAttemptFor(enc)
-				if won, err := enc.SubmitAttempt(test); err != nil {
+				fb, err := enc.Attempt(test)
+				if err != nil {
 					fmt.Println("erreur:", err)
 					return
-				} else {
-					// On envoie la tentative avec le résultat (Won) dans le canal
+				}
+				if enc.State() == StateWon || enc.State() == StateLost {
+					// Issue connue (WON/LOST): on envoie la tentative avec le résultat dans le canal
 					attempts <- Attempts{
 						Player: *p,
 						Word:   ev.Word,
 						Round:  ev.Round,
-						Won:    won,
+						Won:    fb.Correct,
 					}
+				} else {
+					fmt.Printf("[Round %d] Tentative manquée, %d restante(s)\n", ev.Round, fb.Remaining)
 				}
-			} else {
-				enc.Phase = StateEncounter
 			}
 
-			// Fenêtre de combat: première tentative reçue ⇒ victoire/défaite, sinon timeout
+			// Fenêtre de combat: tentative décisive reçue ⇒ victoire/défaite, sinon timeout
 			select {
 			case att := <-attempts:
-				// Résolution (CAPTURED/FLED) puis retour à IDLE
+				// Résolution (CAPTURED/FLED, capture et XP comprises) puis retour à IDLE
 				if err := enc.Resolve(); err != nil {
 					fmt.Printf("[Round %d] Erreur de résolution pour %q: %v\n", att.Round, enc.Word.Text, err)
 				}
+				for _, up := range enc.LevelUps {
+					fmt.Printf("[Annonce] %s passe niveau %d !\n", p.Name, up.Level)
+				}
 				issue := map[bool]string{true: "VICTOIRE", false: "DEFAITE"}[att.Won]
-				if att.Won {
-					if points, err := Capture(p, att.Word); err != nil {
-						fmt.Printf("[Round %d] Erreur: %q \n", att.Round, err)
-					} else if points > 0 {
-						fmt.Printf("[Round %d] Capture de %q\n", att.Round, att.Word.Text)
-					}
+				if enc.State() == StateCaptured {
+					fmt.Printf("[Round %d] Capture de %q\n", att.Round, att.Word.Text)
 				}
 				fmt.Printf("[Annonce] Issue du combat (round %d) pour %q: joueur %s → %s\n",
 					att.Round, att.Word.Text, att.Player.Name, issue)
 
-			case <-time.After(battleTimeout):
+			case <-clock.After(battleTimeout):
 				// Aucune tentative dans le délai → le WordMon s’enfuit
+				_ = enc.Expire(clock.Now())
 				fmt.Printf("[Round %d] %q s’est enfui (timeout %s) → état=%s\n",
 					ev.Round, enc.Word.Text, battleTimeout, enc.State())
 				// Annonce globale
@@ -48,7 +48,7 @@ func TestAwardXP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			player := &Player{XP: tt.initialXP, Level: LevelFromXP(tt.initialXP)}
 
-			err := AwardXP(player, tt.points)
+			_, err := AwardXP(player, tt.points)
 
 			if tt.expectError {
 				if err == nil {
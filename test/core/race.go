@@ -0,0 +1,42 @@
+// Package core contient les modes de capture des apparitions WordMon.
+// En mode course, le premier joueur qui résout le défi remporte le mot et
+// les autres combats sur cette apparition se terminent sans capture.
+package core
+
+// CaptureMode indique qui peut capturer une apparition.
+type CaptureMode string
+
+const (
+	// CaptureShared laisse chaque joueur capturer le mot (comportement historique).
+	CaptureShared CaptureMode = "shared"
+	// CaptureRace réserve le mot au premier joueur qui résout le défi.
+	CaptureRace CaptureMode = "race"
+)
+
+// CapturePolicy choisit le mode de capture d'une apparition selon la rareté du mot.
+// ByRarity prime sur Default; sans réglage, le mode est CaptureShared.
+type CapturePolicy struct {
+	Default  CaptureMode
+	ByRarity map[Rarity]CaptureMode
+}
+
+// ModeFor retourne le mode de capture d'un mot de rareté r.
+func (c CapturePolicy) ModeFor(r Rarity) CaptureMode {
+	if mode, ok := c.ByRarity[r]; ok && mode != "" {
+		return mode
+	}
+	if c.Default != "" {
+		return c.Default
+	}
+	return CaptureShared
+}
+
+// Forfeit termine le combat sans capture: le mot a été remporté par un autre joueur.
+// Sans effet si la rencontre est déjà terminée (CAPTURED, FLED) ou au repos.
+func (e *Encounter) Forfeit() error {
+	switch e.Phase {
+	case StateEncounter, StateInBattle, StateWon, StateLost:
+		return e.Transition(StateFled)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package core
+
+import "testing"
+
+func TestCapturePolicy_ModeFor(t *testing.T) {
+	policy := CapturePolicy{ByRarity: map[Rarity]CaptureMode{Legendary: CaptureRace}}
+
+	tests := []struct {
+		name     string
+		policy   CapturePolicy
+		rarity   Rarity
+		expected CaptureMode
+	}{
+		{"Sans réglage", CapturePolicy{}, Common, CaptureShared},
+		{"Rareté en course", policy, Legendary, CaptureRace},
+		{"Rareté absente", policy, Common, CaptureShared},
+		{"Défaut", CapturePolicy{Default: CaptureRace}, Rare, CaptureRace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ModeFor(tt.rarity); got != tt.expected {
+				t.Errorf("ModeFor(%s) = %q, attendu %q", tt.rarity, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncounterManager_ForfeitByID(t *testing.T) {
+	registry := NewChallengeRegistry()
+	_ = registry.Register("fake", func() Challenge { return &fakeChallenge{answer: "ok"} })
+	m := NewEncounterManager(&Rules{Challenges: registry, Selection: FixedSelection{Default: "fake"}})
+
+	winner, loser := NewPlayer("Ash"), NewPlayer("Gary")
+	winner.ID, loser.ID = "ash", "gary"
+	spawn := SpawnEvent{ID: "s1", Round: 1, Word: Word{Text: "chat", Rarity: Legendary, Points: 5}}
+	a, _ := m.Engage(&winner, spawn)
+	b, _ := m.Engage(&loser, spawn)
+	if a.ID == b.ID {
+		t.Fatal("chaque joueur devrait avoir sa propre rencontre")
+	}
+
+	// les deux joueurs résolvent le défi; seul le premier capture
+	for _, id := range []string{a.ID, b.ID} {
+		if fb, _, err := m.AttemptByID(id, "ok"); err != nil || !fb.Correct {
+			t.Fatalf("AttemptByID(%s) = %+v, %v ; attendu une bonne réponse", id, fb, err)
+		}
+	}
+	if info, err := m.ResolveByID(a.ID, &winner); err != nil || info.Phase != StateCaptured {
+		t.Fatalf("ResolveByID = %s, %v ; attendu CAPTURED", info.Phase, err)
+	}
+	info, err := m.ForfeitByID(b.ID)
+	if err != nil || info.Phase != StateFled {
+		t.Fatalf("ForfeitByID = %s, %v ; attendu FLED", info.Phase, err)
+	}
+	if loser.Inventory["chat"] != 0 || loser.XP != 0 {
+		t.Errorf("le perdant de la course ne devrait rien gagner: %+v", loser)
+	}
+
+	// la rencontre terminée reste sur le même spawn
+	if again, _ := m.Engage(&loser, spawn); again.ID != b.ID || again.Phase != StateFled {
+		t.Errorf("Engage sur le même spawn = %s (%s), attendu la rencontre %s terminée", again.ID, again.Phase, b.ID)
+	}
+}
@@ -0,0 +1,61 @@
+// Package core contient la source de hasard injectable du jeu WordMon.
+// Une source initialisée avec une graine rend une partie entièrement reproductible.
+package core
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Rand est la source de hasard utilisée par core.
+type Rand interface {
+	Intn(n int) int
+}
+
+// GlobalRand utilise la source globale de math/rand (non reproductible).
+var GlobalRand Rand = globalRand{}
+
+type globalRand struct{}
+
+func (globalRand) Intn(n int) int { return rand.Intn(n) }
+
+// NewRand crée une source de hasard reproductible à partir d'une graine.
+// Elle est sûre pour un usage concurrent.
+func NewRand(seed int64) Rand {
+	return &lockedRand{r: rand.New(rand.NewSource(seed))}
+}
+
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (l *lockedRand) Intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Intn(n)
+}
+
+// RandUser est implémenté par les défis qui tirent des valeurs au hasard.
+// Rules leur transmet sa source avant de les initialiser.
+type RandUser interface {
+	UseRand(r Rand)
+}
+
+// orGlobal retourne r, ou GlobalRand si r est nil.
+func orGlobal(r Rand) Rand {
+	if r == nil {
+		return GlobalRand
+	}
+	return r
+}
+
+// perm retourne une permutation aléatoire de [0, n) (Fisher-Yates).
+func perm(r Rand, n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		j := r.Intn(i + 1)
+		p[i], p[j] = p[j], i
+	}
+	return p
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewRand_Reproducible(t *testing.T) {
+	words := []Word{
+		{Text: "chat", Rarity: Common}, {Text: "chien", Rarity: Common},
+		{Text: "dragon", Rarity: Rare}, {Text: "phenix", Rarity: Legendary},
+	}
+	session := func(seed int64) []string {
+		sp, err := NewSpawner(SpawnerSettings{
+			Weights: map[Rarity]int{Common: 5, Rare: 3, Legendary: 1},
+			Words:   words,
+			Rand:    NewRand(seed),
+		})
+		if err != nil {
+			t.Fatalf("NewSpawner: %v", err)
+		}
+		var out []string
+		for i := 0; i < 20; i++ {
+			out = append(out, sp.Spawn().Text)
+		}
+		return out
+	}
+
+	if a, b := session(42), session(42); !reflect.DeepEqual(a, b) {
+		t.Errorf("même graine, tirages différents:\n%v\n%v", a, b)
+	}
+}
+
+func TestNewRand_ReproducibleIDs(t *testing.T) {
+	ids := func(seed int64) []string {
+		rules := DefaultRules()
+		rules.Rand = NewRand(seed)
+		sp, err := NewSpawner(SpawnerSettings{
+			Weights: map[Rarity]int{Common: 1},
+			Words:   []Word{{Text: "chat", Rarity: Common}},
+			Rand:    rules.Rand,
+		})
+		if err != nil {
+			t.Fatalf("NewSpawner: %v", err)
+		}
+		rules.Spawner = sp
+		m := NewEncounterManager(rules)
+		p := NewPlayer("sacha")
+		ev := sp.Next(time.Now())
+		info, err := m.Engage(&p, ev)
+		if err != nil {
+			t.Fatalf("Engage: %v", err)
+		}
+		return []string{ev.ID, info.ID, sp.Next(time.Now()).ID}
+	}
+
+	a, b := ids(7), ids(7)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("même graine, identifiants différents:\n%v\n%v", a, b)
+	}
+	if a[0] == a[2] {
+		t.Errorf("identifiants de spawn identiques: %v", a)
+	}
+}
+
+func TestATrouChallenge_UseRand(t *testing.T) {
+	mask := func(seed int64) string {
+		a := NewATrouChallenge(ATrouSettings{RevealedLetters: map[Rarity]int{Common: 3}, MaxAttempts: 1})
+		a.UseRand(NewRand(seed))
+		a.ResetFor(Common, Word{Text: "dragonnier", Rarity: Common})
+		return a.Mask()
+	}
+	if a, b := mask(7), mask(7); a != b {
+		t.Errorf("même graine, masques différents: %q et %q", a, b)
+	}
+}
+
+func TestPerm(t *testing.T) {
+	p := perm(NewRand(1), 10)
+	seen := map[int]bool{}
+	for _, v := range p {
+		if v < 0 || v >= 10 || seen[v] {
+			t.Fatalf("perm invalide: %v", p)
+		}
+		seen[v] = true
+	}
+}
@@ -0,0 +1,142 @@
+// Package core contient le registre des défis WordMon.
+// Chaque type de défi s'y enregistre sous un nom avec une fabrique, et une
+// politique de sélection choisit quel défi affronter pour un mot donné.
+package core
+
+import (
+	"sort"
+	"sync"
+)
+
+// Noms des défis intégrés.
+const (
+	ChallengeAnagram = "anagram"
+	ChallengeATrou   = "atrou"
+)
+
+// ChallengeFactory crée une nouvelle instance de défi, prête à être réinitialisée.
+type ChallengeFactory func() Challenge
+
+// ChallengeRegistry associe des noms de défis à leurs fabriques.
+// Il est sûr pour un usage concurrent.
+type ChallengeRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]ChallengeFactory
+}
+
+// NewChallengeRegistry crée un registre vide.
+func NewChallengeRegistry() *ChallengeRegistry {
+	return &ChallengeRegistry{factories: make(map[string]ChallengeFactory)}
+}
+
+// NewBuiltinRegistry crée un registre contenant l'anagramme et le défi "à trou".
+// Chaque défi est construit à partir de sa section de ChallengeSettings.
+func NewBuiltinRegistry(s ChallengeSettings) *ChallengeRegistry {
+	r := NewChallengeRegistry()
+	_ = r.Register(ChallengeAnagram, func() Challenge { return NewAnagramChallenge(s.Anagram) })
+	_ = r.Register(ChallengeATrou, func() Challenge { return NewATrouChallenge(s.ATrou) })
+	return r
+}
+
+// DefaultRegistry contient les défis intégrés avec leurs réglages par défaut.
+var DefaultRegistry = NewBuiltinRegistry(DefaultChallengeSettings())
+
+// Register enregistre une fabrique sous un nom.
+// Un nom vide, une fabrique nil ou un nom déjà pris sont refusés.
+func (r *ChallengeRegistry) Register(name string, f ChallengeFactory) error {
+	if name == "" || f == nil {
+		return &ChallengeRegistryError{Name: name, Reason: "nom et fabrique requis"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[name]; exists {
+		return &ChallengeRegistryError{Name: name, Reason: "déjà enregistré"}
+	}
+	r.factories[name] = f
+	return nil
+}
+
+// Has indique si un défi est enregistré sous ce nom.
+func (r *ChallengeRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.factories[name]
+	return ok
+}
+
+// Names retourne les noms enregistrés, triés.
+func (r *ChallengeRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New crée une instance du défi enregistré sous ce nom.
+func (r *ChallengeRegistry) New(name string) (Challenge, error) {
+	r.mu.RLock()
+	f, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &ChallengeRegistryError{Name: name, Reason: "défi inconnu"}
+	}
+	return f(), nil
+}
+
+// SelectionPolicy choisit le nom du défi à utiliser pour un mot.
+// r est la source de hasard des règles, ignorée par les politiques déterministes.
+type SelectionPolicy interface {
+	Select(w Word, r Rand) string
+}
+
+// FixedSelection associe un défi fixe à chaque rareté.
+// Default est utilisé pour les raretés absentes de ByRarity.
+type FixedSelection struct {
+	ByRarity map[Rarity]string
+	Default  string
+}
+
+// Select retourne le défi associé à la rareté du mot.
+func (f FixedSelection) Select(w Word, _ Rand) string {
+	if name, ok := f.ByRarity[w.Rarity]; ok {
+		return name
+	}
+	return f.Default
+}
+
+// WeightedSelection tire un défi au hasard selon des poids par rareté.
+// Default est utilisé quand aucun poids positif n'est défini pour la rareté.
+type WeightedSelection struct {
+	Weights map[Rarity]map[string]int
+	Default string
+}
+
+// Select tire un défi proportionnellement à son poids pour la rareté du mot.
+func (ws WeightedSelection) Select(w Word, r Rand) string {
+	weights := ws.Weights[w.Rarity]
+	names := make([]string, 0, len(weights))
+	total := 0
+	for name, weight := range weights {
+		if weight > 0 {
+			names = append(names, name)
+			total += weight
+		}
+	}
+	if total == 0 {
+		return ws.Default
+	}
+	// ordre stable pour que le tirage ne dépende que du hasard
+	sort.Strings(names)
+	x := orGlobal(r).Intn(total)
+	for _, name := range names {
+		x -= weights[name]
+		if x < 0 {
+			return name
+		}
+	}
+	return ws.Default
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeChallenge est un défi de test qui accepte une réponse fixe.
+type fakeChallenge struct {
+	answer string
+	word   Word
+}
+
+func (f *fakeChallenge) Instructions() string               { return "dis " + f.answer }
+func (f *fakeChallenge) ResetFor(r Rarity, w Word)          { f.word = w }
+func (f *fakeChallenge) Check(attempt string) (bool, error) { return attempt == f.answer, nil }
+
+func TestChallengeRegistry_Register(t *testing.T) {
+	registry := NewChallengeRegistry()
+
+	if err := registry.Register("fake", func() Challenge { return &fakeChallenge{} }); err != nil {
+		t.Fatalf("Register ne devrait pas échouer: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		factory ChallengeFactory
+	}{
+		{"Nom déjà pris", "fake", func() Challenge { return &fakeChallenge{} }},
+		{"Nom vide", "", func() Challenge { return &fakeChallenge{} }},
+		{"Fabrique nil", "other", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register(tt.key, tt.factory)
+			var regErr *ChallengeRegistryError
+			if !errors.As(err, &regErr) {
+				t.Errorf("Register devrait retourner une ChallengeRegistryError, got %v", err)
+			}
+		})
+	}
+
+	if !registry.Has("fake") || registry.Has("other") {
+		t.Errorf("Has incohérent, noms = %v", registry.Names())
+	}
+}
+
+func TestChallengeRegistry_New(t *testing.T) {
+	registry := NewBuiltinRegistry(DefaultChallengeSettings())
+
+	names := registry.Names()
+	if len(names) != 2 || names[0] != ChallengeAnagram || names[1] != ChallengeATrou {
+		t.Errorf("Names() = %v, attendu [anagram atrou]", names)
+	}
+
+	ch, err := registry.New(ChallengeATrou)
+	if err != nil {
+		t.Fatalf("New(atrou) ne devrait pas échouer: %v", err)
+	}
+	if _, ok := ch.(*ATrouChallenge); !ok {
+		t.Errorf("New(atrou) = %T, attendu *ATrouChallenge", ch)
+	}
+
+	if _, err := registry.New("inconnu"); err == nil {
+		t.Error("New devrait échouer pour un défi inconnu")
+	}
+}
+
+func TestFixedSelection_Select(t *testing.T) {
+	policy := FixedSelection{
+		ByRarity: map[Rarity]string{Legendary: ChallengeATrou},
+		Default:  ChallengeAnagram,
+	}
+
+	if got := policy.Select(Word{Rarity: Legendary}, nil); got != ChallengeATrou {
+		t.Errorf("Select(Legendary) = %q, attendu %q", got, ChallengeATrou)
+	}
+	if got := policy.Select(Word{Rarity: Common}, nil); got != ChallengeAnagram {
+		t.Errorf("Select(Common) = %q, attendu %q", got, ChallengeAnagram)
+	}
+}
+
+func TestWeightedSelection_Select(t *testing.T) {
+	policy := WeightedSelection{
+		Weights: map[Rarity]map[string]int{
+			Common: {ChallengeAnagram: 1, ChallengeATrou: 1},
+			Rare:   {ChallengeATrou: 5, ChallengeAnagram: 0},
+		},
+		Default: ChallengeAnagram,
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		seen[policy.Select(Word{Rarity: Common}, nil)] = true
+		if got := policy.Select(Word{Rarity: Rare}, nil); got != ChallengeATrou {
+			t.Fatalf("Select(Rare) = %q, seul atrou a un poids positif", got)
+		}
+	}
+	if !seen[ChallengeAnagram] || !seen[ChallengeATrou] {
+		t.Errorf("Select(Common) devrait tirer les deux défis, got %v", seen)
+	}
+	if got := policy.Select(Word{Rarity: Legendary}, nil); got != ChallengeAnagram {
+		t.Errorf("Select(Legendary) = %q, attendu le défaut", got)
+	}
+}
+
+func TestEncounter_BeginBattle_UsesRegistry(t *testing.T) {
+	registry := NewChallengeRegistry()
+	_ = registry.Register("fake", func() Challenge { return &fakeChallenge{answer: "ok"} })
+	enc := Encounter{
+		Phase: StateEncounter,
+		Word:  Word{Text: "test", Rarity: Rare},
+		Rules: &Rules{
+			Challenges: registry,
+			Selection:  FixedSelection{ByRarity: map[Rarity]string{Rare: "fake"}},
+		},
+	}
+
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle ne devrait pas échouer: %v", err)
+	}
+	if enc.ChallengeName != "fake" {
+		t.Errorf("ChallengeName = %q, attendu %q", enc.ChallengeName, "fake")
+	}
+	if fake, ok := enc.Challenge.(*fakeChallenge); !ok || fake.word.Text != "test" {
+		t.Errorf("Challenge = %#v, attendu un fakeChallenge réinitialisé pour le mot", enc.Challenge)
+	}
+	if won, _ := enc.SubmitAttempt("ok"); !won || enc.Phase != StateWon {
+		t.Errorf("SubmitAttempt(ok) = %v, phase %s ; attendu victoire", won, enc.Phase)
+	}
+}
+
+func TestEncounter_BeginBattle_UnknownChallenge(t *testing.T) {
+	enc := Encounter{
+		Phase: StateEncounter,
+		Word:  Word{Text: "test", Rarity: Common},
+		Rules: &Rules{Challenges: NewChallengeRegistry(), Selection: FixedSelection{Default: "absent"}},
+	}
+
+	if err := enc.BeginBattle(); err == nil {
+		t.Error("BeginBattle devrait échouer si le défi choisi n'est pas enregistré")
+	}
+	if enc.Phase != StateEncounter {
+		t.Errorf("Phase ne devrait pas changer, got %s", enc.Phase)
+	}
+}
@@ -0,0 +1,63 @@
+// Package core contient les montées de niveau et leurs récompenses.
+// Chaque niveau franchi produit un LevelUp portant la récompense configurée
+// pour ce niveau (objets bonus, raretés débloquées, titre).
+package core
+
+// Reward décrit la récompense accordée en atteignant un niveau.
+type Reward struct {
+	Items          map[string]int
+	UnlockRarities []Rarity
+	Title          string
+}
+
+// IsZero indique si la récompense est vide.
+func (r Reward) IsZero() bool {
+	return len(r.Items) == 0 && len(r.UnlockRarities) == 0 && r.Title == ""
+}
+
+// RewardTable associe à un niveau la récompense accordée en l'atteignant.
+type RewardTable map[int]Reward
+
+// LevelUp est l'événement produit pour chaque niveau franchi.
+type LevelUp struct {
+	Level  int
+	Reward Reward
+}
+
+// applyTo ajoute la récompense au joueur: objets cumulés, raretés et titres sans doublon.
+func (r Reward) applyTo(p *Player) {
+	if len(r.Items) > 0 && p.Items == nil {
+		p.Items = make(map[string]int, len(r.Items))
+	}
+	for item, n := range r.Items {
+		p.Items[item] += n
+	}
+	for _, rar := range r.UnlockRarities {
+		if !p.HasUnlocked(rar) {
+			p.Unlocked = append(p.Unlocked, rar)
+		}
+	}
+	if r.Title != "" && !containsString(p.Titles, r.Title) {
+		p.Titles = append(p.Titles, r.Title)
+	}
+}
+
+// HasUnlocked indique si le joueur a débloqué la rareté.
+func (p Player) HasUnlocked(r Rarity) bool {
+	for _, u := range p.Unlocked {
+		if u == r {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString indique si s figure dans list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAwardXPWith_LevelUps(t *testing.T) {
+	rewards := RewardTable{
+		2: {Items: map[string]int{"potion": 1}},
+		3: {UnlockRarities: []Rarity{Rare}, Title: "Apprenti"},
+		4: {Items: map[string]int{"potion": 2}, UnlockRarities: []Rarity{Rare}},
+	}
+	p := &Player{XP: 50, Level: 1}
+
+	// 50 + 280 = 330 XP: niveaux 2, 3 et 4 franchis d'un coup
+	ups, err := AwardXPWith(p, 280, DefaultLevelCurve, rewards)
+	if err != nil {
+		t.Fatalf("AwardXPWith: %v", err)
+	}
+	var levels []int
+	for _, up := range ups {
+		levels = append(levels, up.Level)
+	}
+	if !reflect.DeepEqual(levels, []int{2, 3, 4}) {
+		t.Fatalf("niveaux franchis = %v, attendu [2 3 4]", levels)
+	}
+	if ups[1].Reward.Title != "Apprenti" {
+		t.Errorf("récompense du niveau 3 = %+v", ups[1].Reward)
+	}
+	if p.XP != 330 || p.Level != 4 {
+		t.Errorf("XP=%d Level=%d, attendu 330 et 4", p.XP, p.Level)
+	}
+	if p.Items["potion"] != 3 {
+		t.Errorf("potions = %d, attendu 3", p.Items["potion"])
+	}
+	if !reflect.DeepEqual(p.Unlocked, []Rarity{Rare}) {
+		t.Errorf("raretés débloquées = %v, attendu [Rare] sans doublon", p.Unlocked)
+	}
+	if !reflect.DeepEqual(p.Titles, []string{"Apprenti"}) {
+		t.Errorf("titres = %v", p.Titles)
+	}
+}
+
+func TestAwardXPWith_NoLevelUp(t *testing.T) {
+	p := &Player{XP: 10, Level: 1}
+	ups, err := AwardXPWith(p, 20, DefaultLevelCurve, RewardTable{2: {Title: "x"}})
+	if err != nil {
+		t.Fatalf("AwardXPWith: %v", err)
+	}
+	if len(ups) != 0 || len(p.Titles) != 0 {
+		t.Errorf("aucune montée attendue, got %v (titres %v)", ups, p.Titles)
+	}
+}
+
+func TestAwardXPWith_NegativeLeavesPlayerUnchanged(t *testing.T) {
+	p := &Player{XP: 90, Level: 1}
+	if _, err := AwardXPWith(p, -5, DefaultLevelCurve, RewardTable{2: {Title: "x"}}); err == nil {
+		t.Fatal("une erreur était attendue pour des points négatifs")
+	}
+	if p.XP != 90 || p.Level != 1 || p.Titles != nil {
+		t.Errorf("joueur modifié malgré l'erreur: %+v", p)
+	}
+}
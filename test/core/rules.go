@@ -0,0 +1,69 @@
+// Package core contient les règles de jeu appliquées aux rencontres WordMon.
+// Elles rassemblent les choix configurables (défis, sélection, tentatives) en un seul objet.
+package core
+
+import "fmt"
+
+// Rules regroupe les règles partagées par les rencontres.
+// Une rencontre sans Rules utilise DefaultRules(); sans Spawner, les pools
+// intégrées alimentent Encounter.Start. Rand et Clock, nil par défaut
+// (hasard global et temps réel), rendent une partie reproductible. Levels
+// détermine la progression des joueurs (DefaultLevelCurve si nil) et Rewards
+// les récompenses des niveaux franchis. Events, s'il est défini, journalise
+// apparitions, transitions, tentatives et captures. Capture indique, par
+// rareté, si un mot peut être capturé par tous ou par le premier qui le résout.
+type Rules struct {
+	Challenges *ChallengeRegistry
+	Selection  SelectionPolicy
+	Attempts   AttemptBudget
+	Spawner    *Spawner
+	Rand       Rand
+	Clock      Clock
+	Levels     LevelCurve
+	Rewards    RewardTable
+	Events     EventLog
+	Capture    CapturePolicy
+}
+
+// DefaultRules retourne les règles historiques: anagramme pour toutes les raretés,
+// une seule tentative par combat.
+func DefaultRules() *Rules {
+	return &Rules{
+		Challenges: DefaultRegistry,
+		Selection:  FixedSelection{Default: ChallengeAnagram},
+	}
+}
+
+// NewChallenge choisit, crée et initialise le défi adapté au mot.
+// Retourne aussi le nom du défi retenu.
+func (r *Rules) NewChallenge(w Word) (Challenge, string, error) {
+	name := r.Selection.Select(w, r.rng())
+	ch, err := r.Challenges.New(name)
+	if err != nil {
+		return nil, name, fmt.Errorf("sélection du défi pour %q: %w", w.Text, err)
+	}
+	if u, ok := ch.(RandUser); ok {
+		u.UseRand(r.rng())
+	}
+	ch.ResetFor(w.Rarity, w)
+	return ch, name, nil
+}
+
+// LevelCurve retourne la courbe de progression des règles, ou DefaultLevelCurve.
+func (r *Rules) LevelCurve() LevelCurve {
+	if r.Levels == nil {
+		return DefaultLevelCurve
+	}
+	return r.Levels
+}
+
+// rng retourne la source de hasard des règles, ou GlobalRand.
+func (r *Rules) rng() Rand { return orGlobal(r.Rand) }
+
+// clock retourne l'horloge des règles, ou SystemClock.
+func (r *Rules) clock() Clock {
+	if r.Clock == nil {
+		return SystemClock
+	}
+	return r.Clock
+}
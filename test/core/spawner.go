@@ -0,0 +1,102 @@
+// Package core contient le spawner de WordMon piloté par la configuration.
+// Il tire une rareté selon des poids puis un mot du dictionnaire de cette rareté.
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SpawnerSettings regroupe les réglages d'un Spawner.
+// Weights donne le poids de chaque rareté, Points l'XP d'un mot selon sa rareté
+// (Word.Points conservé si absent), Words le dictionnaire et Flee les délais de fuite.
+// Rand et Clock sont optionnels: hasard global et temps réel par défaut.
+type SpawnerSettings struct {
+	Weights map[Rarity]int
+	Points  map[Rarity]int
+	Words   []Word
+	Flee    FleePolicy
+	Rand    Rand
+	Clock   Clock
+}
+
+// Spawner fait apparaître des mots selon des poids de rareté.
+// Il est sûr pour un usage concurrent.
+type Spawner struct {
+	rarities []Rarity
+	weights  map[Rarity]int
+	total    int
+	pools    map[Rarity][]Word
+	flee     FleePolicy
+	rng      Rand
+	ids      Rand // source des identifiants, nil: crypto/rand
+	clock    Clock
+
+	mu    sync.Mutex
+	round int
+}
+
+// NewSpawner crée un spawner à partir des réglages.
+// Chaque rareté de poids positif doit disposer d'au moins un mot.
+func NewSpawner(s SpawnerSettings) (*Spawner, error) {
+	sp := &Spawner{
+		weights: make(map[Rarity]int),
+		pools:   make(map[Rarity][]Word),
+		flee:    s.Flee,
+		rng:     orGlobal(s.Rand),
+		ids:     s.Rand,
+		clock:   s.Clock,
+	}
+	if sp.clock == nil {
+		sp.clock = SystemClock
+	}
+	for _, w := range s.Words {
+		if pts, ok := s.Points[w.Rarity]; ok {
+			w.Points = pts
+		}
+		sp.pools[w.Rarity] = append(sp.pools[w.Rarity], w)
+	}
+	for r, weight := range s.Weights {
+		if weight <= 0 {
+			continue
+		}
+		if len(sp.pools[r]) == 0 {
+			return nil, &SpawnerError{Reason: fmt.Sprintf("aucun mot pour la rareté %s (poids %d)", r, weight)}
+		}
+		sp.rarities = append(sp.rarities, r)
+		sp.weights[r] = weight
+		sp.total += weight
+	}
+	if sp.total == 0 {
+		return nil, &SpawnerError{Reason: "aucune rareté avec un poids positif"}
+	}
+	// ordre stable pour que le tirage ne dépende que du hasard
+	sort.Slice(sp.rarities, func(i, j int) bool { return sp.rarities[i] < sp.rarities[j] })
+	return sp, nil
+}
+
+// Spawn tire une rareté selon les poids, puis un mot de cette rareté.
+func (s *Spawner) Spawn() Word {
+	x := s.rng.Intn(s.total)
+	for _, r := range s.rarities {
+		x -= s.weights[r]
+		if x < 0 {
+			pool := s.pools[r]
+			return pool[s.rng.Intn(len(pool))]
+		}
+	}
+	// inatteignable: la somme des poids vaut total
+	panic("spawner: tirage de rareté hors bornes")
+}
+
+// Next fait apparaître le mot du round suivant, avec un identifiant unique et son échéance de fuite.
+// Avec une source de hasard fournie (Rand), mots et identifiants sont reproductibles.
+func (s *Spawner) Next(now time.Time) SpawnEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.Spawn()
+	s.round++
+	return SpawnEvent{ID: newID(s.ids), Round: s.round, Word: w, ExpiresAt: s.flee.Deadline(w.Rarity, now)}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testSpawnerWords() []Word {
+	return []Word{
+		{ID: "c_1", Text: "chat", Rarity: Common},
+		{ID: "c_2", Text: "lune", Rarity: Common},
+		{ID: "r_1", Text: "phare", Rarity: Rare},
+		{ID: "l_1", Text: "sphinx", Rarity: Legendary},
+	}
+}
+
+func TestNewSpawner_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings SpawnerSettings
+	}{
+		{"Sans poids", SpawnerSettings{Words: testSpawnerWords()}},
+		{"Poids nuls", SpawnerSettings{Weights: map[Rarity]int{Common: 0}, Words: testSpawnerWords()}},
+		{"Rareté sans mot", SpawnerSettings{Weights: map[Rarity]int{Common: 50, Legendary: 50}, Words: testSpawnerWords()[:2]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSpawner(tt.settings)
+			var spErr *SpawnerError
+			if !errors.As(err, &spErr) {
+				t.Errorf("NewSpawner() = %v, attendu une SpawnerError", err)
+			}
+		})
+	}
+}
+
+func TestSpawner_Spawn(t *testing.T) {
+	sp, err := NewSpawner(SpawnerSettings{
+		Weights: map[Rarity]int{Common: 50, Rare: 50, Legendary: 0},
+		Points:  map[Rarity]int{Common: 5, Rare: 20},
+		Words:   testSpawnerWords(),
+	})
+	if err != nil {
+		t.Fatalf("NewSpawner: %v", err)
+	}
+
+	seen := map[Rarity]bool{}
+	for i := 0; i < 200; i++ {
+		w := sp.Spawn()
+		seen[w.Rarity] = true
+		switch w.Rarity {
+		case Common:
+			if w.Points != 5 {
+				t.Fatalf("Points(%s) = %d, attendu 5", w.Text, w.Points)
+			}
+		case Rare:
+			if w.Points != 20 {
+				t.Fatalf("Points(%s) = %d, attendu 20", w.Text, w.Points)
+			}
+		default:
+			t.Fatalf("rareté %s tirée malgré un poids nul", w.Rarity)
+		}
+	}
+	if !seen[Common] || !seen[Rare] {
+		t.Errorf("les deux raretés pondérées devraient apparaître, got %v", seen)
+	}
+}
+
+func TestSpawner_Next(t *testing.T) {
+	sp, err := NewSpawner(SpawnerSettings{
+		Weights: map[Rarity]int{Legendary: 1},
+		Words:   testSpawnerWords(),
+		Flee:    FleePolicy{After: time.Second, ScaleByRarity: map[Rarity]float64{Legendary: 3}},
+	})
+	if err != nil {
+		t.Fatalf("NewSpawner: %v", err)
+	}
+	now := time.Now()
+
+	first, second := sp.Next(now), sp.Next(now)
+
+	if first.Round != 1 || second.Round != 2 {
+		t.Errorf("Rounds = %d, %d ; attendu 1, 2", first.Round, second.Round)
+	}
+	if first.Word.Text != "sphinx" {
+		t.Errorf("Word = %q, attendu sphinx", first.Word.Text)
+	}
+	if !first.ExpiresAt.Equal(now.Add(3 * time.Second)) {
+		t.Errorf("ExpiresAt = %v, attendu %v", first.ExpiresAt, now.Add(3*time.Second))
+	}
+}
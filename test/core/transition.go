@@ -0,0 +1,76 @@
+// Package core contient la table de transitions des rencontres WordMon.
+// Chaque changement d'état passe par Transition, qui valide le passage, l'horodate
+// dans l'historique de la rencontre et prévient les observateurs.
+package core
+
+import "time"
+
+// transitions liste, pour chaque état, les états atteignables.
+// CAPTURED et FLED sont terminaux pour un mot: seule une nouvelle rencontre
+// (ENCOUNTERED) ou un retour au repos (IDLE) en sortent. WON peut encore mener
+// à FLED quand un autre joueur a remporté la course (Forfeit).
+var transitions = map[State][]State{
+	StateIdle:      {StateEncounter},
+	StateEncounter: {StateInBattle, StateFled},
+	StateInBattle:  {StateWon, StateLost, StateFled},
+	StateWon:       {StateCaptured, StateFled},
+	StateLost:      {StateFled},
+	StateCaptured:  {StateIdle, StateEncounter},
+	StateFled:      {StateIdle, StateEncounter},
+}
+
+// CanTransition indique si la table autorise le passage de from à to.
+func CanTransition(from, to State) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStates retourne les états atteignables depuis from.
+func NextStates(from State) []State {
+	return append([]State(nil), transitions[from]...)
+}
+
+// StateChange décrit une transition de la rencontre, horodatée avec l'horloge des règles.
+type StateChange struct {
+	From State
+	To   State
+	At   time.Time
+}
+
+// Observer est appelé après chaque transition d'une rencontre.
+type Observer func(e *Encounter, change StateChange)
+
+// Subscribe enregistre un observateur appelé à chaque transition.
+func (e *Encounter) Subscribe(o Observer) {
+	if o != nil {
+		e.observers = append(e.observers, o)
+	}
+}
+
+// Transition fait passer la rencontre dans l'état to si la table l'autorise.
+// Le changement est ajouté à History puis transmis aux observateurs, dans l'ordre d'abonnement.
+func (e *Encounter) Transition(to State) error {
+	if !CanTransition(e.Phase, to) {
+		return &InvalidTransitionError{From: e.Phase, To: to}
+	}
+	change := StateChange{From: e.Phase, To: to, At: e.rules().clock().Now()}
+	e.Phase = to
+	e.History = append(e.History, change)
+	e.record(Event{Type: EventTransition, Transition: &TransitionRecord{Word: e.Word.Text, From: change.From, To: to}})
+	for _, o := range e.observers {
+		o(e, change)
+	}
+	return nil
+}
+
+// canGo retourne une InvalidTransitionError si to n'est pas atteignable depuis l'état actuel.
+func (e *Encounter) canGo(to State) error {
+	if !CanTransition(e.Phase, to) {
+		return &InvalidTransitionError{From: e.Phase, To: to}
+	}
+	return nil
+}
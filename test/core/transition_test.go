@@ -0,0 +1,117 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to State
+		allowed  bool
+	}{
+		{StateIdle, StateEncounter, true},
+		{StateEncounter, StateInBattle, true},
+		{StateInBattle, StateWon, true},
+		{StateWon, StateCaptured, true},
+		{StateWon, StateFled, true},
+		{StateLost, StateFled, true},
+		{StateCaptured, StateEncounter, true},
+		{StateIdle, StateInBattle, false},
+		{StateWon, StateEncounter, false},
+		{StateCaptured, StateWon, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.allowed {
+			t.Errorf("CanTransition(%s, %s) = %v, attendu %v", tt.from, tt.to, got, tt.allowed)
+		}
+	}
+}
+
+func TestEncounter_Transition_Invalid(t *testing.T) {
+	enc := NewEncounter()
+	err := enc.Transition(StateWon)
+
+	var invalid *InvalidTransitionError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Transition(WON) depuis IDLE = %v, attendu une InvalidTransitionError", err)
+	}
+	if enc.Phase != StateIdle || len(enc.History) != 0 {
+		t.Errorf("une transition refusée ne doit rien modifier: %s, %v", enc.Phase, enc.History)
+	}
+}
+
+func TestEncounter_HistoryAndObservers(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	rules := DefaultRules()
+	rules.Clock = clock
+	player := NewPlayer("Ash")
+	enc := NewEncounter()
+	enc.Rules = rules
+	enc.Player = &player
+
+	var observed []State
+	enc.Subscribe(func(e *Encounter, c StateChange) {
+		if e.Phase != c.To {
+			t.Errorf("l'observateur devrait voir le nouvel état %s, got %s", c.To, e.Phase)
+		}
+		observed = append(observed, c.To)
+	})
+
+	if err := enc.Meet(SpawnEvent{Word: Word{Text: "chat", Rarity: Common, Points: 5}}); err != nil {
+		t.Fatalf("Meet: %v", err)
+	}
+	clock.Advance(time.Second)
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	if _, err := enc.SubmitAttempt("tach"); err != nil {
+		t.Fatalf("SubmitAttempt: %v", err)
+	}
+	if err := enc.Resolve(); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if enc.Phase != StateCaptured {
+		t.Fatalf("Phase = %s, CAPTURED doit rester observable", enc.Phase)
+	}
+	if err := enc.Reset(); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	want := []State{StateEncounter, StateInBattle, StateWon, StateCaptured, StateIdle}
+	if !reflect.DeepEqual(observed, want) {
+		t.Errorf("observateur: %v, attendu %v", observed, want)
+	}
+	if len(enc.History) != len(want) {
+		t.Fatalf("History = %v", enc.History)
+	}
+	if enc.History[0].From != StateIdle || !enc.History[0].At.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("première entrée = %+v", enc.History[0])
+	}
+	if !enc.History[1].At.Equal(enc.History[0].At.Add(time.Second)) {
+		t.Errorf("l'horodatage doit suivre l'horloge des règles: %+v", enc.History[1])
+	}
+}
+
+func TestEncounter_Meet_AbandonsBattle(t *testing.T) {
+	enc := Encounter{Phase: StateEncounter, Word: Word{Text: "chat", Rarity: Common}}
+	if err := enc.BeginBattle(); err != nil {
+		t.Fatalf("BeginBattle: %v", err)
+	}
+	if err := enc.Meet(SpawnEvent{Word: Word{Text: "chien", Rarity: Common}}); err != nil {
+		t.Fatalf("Meet: %v", err)
+	}
+	if enc.Phase != StateEncounter || enc.Word.Text != "chien" || enc.Challenge != nil {
+		t.Errorf("nouvelle rencontre attendue, got %s %q", enc.Phase, enc.Word.Text)
+	}
+	if last := enc.History[len(enc.History)-2]; last.To != StateFled {
+		t.Errorf("le mot précédent devrait avoir fui, got %+v", enc.History)
+	}
+
+	won := Encounter{Phase: StateWon}
+	if err := won.Meet(SpawnEvent{Word: Word{Text: "chat"}}); err == nil {
+		t.Error("Meet depuis WON devrait exiger une résolution préalable")
+	}
+}
@@ -3,7 +3,10 @@
 // et les mécaniques de base du jeu.
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Rarity représente la rareté d'un WordMon.
 type Rarity string
@@ -24,30 +27,49 @@ type Word struct {
 }
 
 // Player représente le dresseur de mots.
-// Un joueur a un identifiant, un nom, de l'expérience, un niveau et un inventaire.
+// Un joueur a un identifiant, un nom, de l'expérience, un niveau et un inventaire,
+// ainsi que les récompenses de niveau obtenues (objets, raretés débloquées, titres).
 type Player struct {
 	ID        string
 	Name      string
 	XP        int
 	Level     int
 	Inventory map[string]int // mot -> quantité
+	Items     map[string]int // objet bonus -> quantité
+	Unlocked  []Rarity
+	Titles    []string
 }
 
 // SpawnEvent représente l'apparition d'un mot dans le jeu.
-// Il contient le numéro du round et le mot qui apparaît.
+// Il contient l'identifiant unique de l'apparition, le numéro du round, le mot
+// qui apparaît et son échéance de fuite (ExpiresAt zéro: le mot ne fuit pas de lui-même).
 type SpawnEvent struct {
-	Round int
-	Word  Word
+	ID        string
+	Round     int
+	Word      Word
+	ExpiresAt time.Time
 }
 
 // Encounter représente une rencontre entre un joueur et un mot.
 // Elle gère l'état de la rencontre, le joueur, le mot et le défi associé.
+// Rules détermine le défi choisi au début du combat (DefaultRules() si nil)
+// et AttemptsLeft le nombre de tentatives encore autorisées dans le combat.
+// Passé Deadline (si non nul), le mot s'enfuit. LevelUps liste les niveaux
+// franchis lors de la dernière capture et History les transitions horodatées.
 type Encounter struct {
-	Phase     State
-	Player    *Player
-	Word      Word
-	Challenge Challenge
-	Cancel    context.CancelFunc
+	Phase         State
+	Player        *Player
+	Word          Word
+	Deadline      time.Time
+	Challenge     Challenge
+	ChallengeName string
+	AttemptsLeft  int
+	Rules         *Rules
+	LevelUps      []LevelUp
+	History       []StateChange
+	Cancel        context.CancelFunc
+
+	observers []Observer
 }
 
 // Attempts représente les tentatives d'un joueur pour capturer un mot.
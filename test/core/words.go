@@ -3,7 +3,6 @@ package core
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"time"
 )
@@ -32,19 +31,16 @@ var poolLegendary = []Word{
 	{ID: "l5", Text: "transcendant", Rarity: Legendary, Points: 100},
 }
 
-// SpawnWord choisit une rareté selon des poids (~80/18/2), puis un mot dans la pool.
+// defaultSpawner utilise les pools intégrées (~80/18/2), à défaut de configuration.
+var defaultSpawner, _ = NewSpawner(SpawnerSettings{
+	Weights: map[Rarity]int{Common: 80, Rare: 18, Legendary: 2},
+	Words:   append(append(append([]Word{}, poolCommon...), poolRare...), poolLegendary...),
+})
+
+// SpawnWord choisit un mot avec le spawner par défaut (pools intégrées, ~80/18/2).
+// Le jeu configuré utilise un Spawner construit depuis game.yaml et words.json.
 func SpawnWord() Word {
-	x := rand.Intn(100)
-	var pool []Word
-	switch {
-	case x < 80:
-		pool = poolCommon
-	case x < 98:
-		pool = poolRare
-	default:
-		pool = poolLegendary
-	}
-	return pool[rand.Intn(len(pool))]
+	return defaultSpawner.Spawn()
 }
 
 // Presentation retourne une fiche textuelle.
@@ -52,21 +48,29 @@ func (w Word) Presentation() string {
 	return string(w.Rarity) + " — \"" + w.Text + "\" (+" + strconv.Itoa(w.Points) + " XP)"
 }
 
-func StartSpawner(ctx context.Context, ch chan<- SpawnEvent, interval time.Duration) {
+// StartSpawner publie un mot du spawner sp à chaque intervalle jusqu'à l'annulation
+// du contexte, puis ferme le canal. Sans spawner (nil), les pools intégrées sont utilisées.
+// Le rythme suit l'horloge du spawner (SpawnerSettings.Clock).
+func StartSpawner(ctx context.Context, sp *Spawner, ch chan<- SpawnEvent, interval time.Duration) {
 	defer close(ch)
-	ticker := time.NewTicker(interval)
+	if sp == nil {
+		sp = defaultSpawner
+	}
+	ticker := sp.clock.NewTicker(interval)
 	defer ticker.Stop()
 
-	round := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			round++
-			w := SpawnWord()
-			fmt.Printf("Un Pokémon a spawn ! Round %d : %s\n", round, w.Text)
-			ch <- SpawnEvent{Round: round, Word: w}
+		case now := <-ticker.C():
+			ev := sp.Next(now)
+			fmt.Printf("Un Pokémon a spawn ! Round %d : %s\n", ev.Round, ev.Word.Text)
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
@@ -222,12 +222,20 @@ func TestStartSpawner(t *testing.T) {
 	defer cancel()
 
 	ch := make(chan SpawnEvent, 10)
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	sp, err := NewSpawner(SpawnerSettings{
+		Weights: map[Rarity]int{Common: 1},
+		Words:   []Word{{Text: "chat", Rarity: Common, Points: 10}},
+		Clock:   clock,
+	})
+	if err != nil {
+		t.Fatalf("NewSpawner: %v", err)
+	}
 
-	// Démarrer le spawner avec un intervalle court
-	go StartSpawner(ctx, ch, 10*time.Millisecond)
-
-	// Attendre un peu pour qu'un spawn se produise
-	time.Sleep(50 * time.Millisecond)
+	// Démarrer le spawner puis avancer l'horloge d'un intervalle, sans attendre
+	go StartSpawner(ctx, sp, ch, 10*time.Millisecond)
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Millisecond)
 
 	// Vérifier qu'au moins un événement a été généré
 	select {
@@ -238,7 +246,7 @@ func TestStartSpawner(t *testing.T) {
 		if event.Word.Text == "" {
 			t.Error("Word.Text ne devrait pas être vide")
 		}
-	default:
+	case <-time.After(time.Second):
 		t.Error("Aucun événement de spawn généré")
 	}
 